@@ -0,0 +1,66 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import "strings"
+
+// AriaCurrent returns the value for the aria-current attribute: "page" for the current page, empty for any other.
+//
+//	<a href="{{.URL}}"{{with .AriaCurrent}} aria-current="{{.}}"{{end}}>{{.Number}}</a>
+func (e Entry) AriaCurrent() string {
+	if e.Active {
+		return "page"
+	}
+	return ""
+}
+
+// Rel returns the value for the rel attribute of the link: "first", "prev", "next" and "last",
+// or a combination like "first prev", depending on the page relative to the current one.
+// It is empty for the current page, a gap and any other page.
+func (e Entry) Rel() string {
+	return e.rel
+}
+
+// Label returns the accessible name of the link, like "Page 3" or "Page 3, current page", from Labels.Page and Labels.Current.
+// It is empty for a gap.
+func (e Entry) Label() string {
+	return e.label
+}
+
+// Label returns the accessible name of the navigation, for the aria-label attribute of the nav element. See Labels.Nav.
+func (p *Pagination) Label() string {
+	return p.Labels().Nav
+}
+
+// rel returns the link relations of page n to the current page.
+func (p *Pagination) rel(n int) string {
+	if n == p.args.Page {
+		return ""
+	}
+	var rel []string
+	if n == 1 {
+		rel = append(rel, "first")
+	}
+	if n == p.Prev() {
+		rel = append(rel, "prev")
+	}
+	if n == p.Next() {
+		rel = append(rel, "next")
+	}
+	if n == p.pages {
+		rel = append(rel, "last")
+	}
+	return strings.Join(rel, " ")
+}
+
+// page returns the accessible name of the link to the page with the formatted number n.
+// The labels are not used as a format, so a label without %d or with other verbs is rendered as is.
+func (l Labels) page(n string, current bool) string {
+	s := l.Page
+	if current {
+		s = l.Current
+	}
+	return strings.Replace(s, "%d", n, -1)
+}
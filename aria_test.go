@@ -0,0 +1,99 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+)
+
+var ariaTests = []struct {
+	a    Args
+	want []string // Rel, AriaCurrent and Label of every entry, separated by "|"
+}{
+	{
+		Args{Max: 4, Pos: 2, Page: 2, Total: 40, Size: 10},
+		[]string{"first prev||Page 1", "|page|Page 2, current page", "next||Page 3", "last||Page 4"},
+	},
+	{
+		Args{Page: 5, Total: 100, Size: 10, Layout: Ellipsis, Boundary: 1, Siblings: 1, Labels: Labels{Page: "Ga naar pagina %d", Current: "Huidige pagina, pagina %d"}},
+		[]string{"first||Ga naar pagina 1", "||", "prev||Ga naar pagina 4", "|page|Huidige pagina, pagina 5", "next||Ga naar pagina 6", "||", "last||Ga naar pagina 10"},
+	},
+	{
+		Args{Max: 3, Pos: 1, Page: 1, Total: 10, Size: 10},
+		[]string{"|page|Page 1, current page"},
+	},
+	{
+		Args{Max: 2, Pos: 1, Page: 1, Total: 20, Size: 10, Labels: Labels{Page: "Go to page", Current: "%s%% of page %d"}},
+		[]string{"|page|%s%% of page 1", "next last||Go to page"},
+	},
+}
+
+func TestAria(t *testing.T) {
+	for _, at := range ariaTests {
+		p, err := New(at.a)
+		if err != nil {
+			t.Error("New() for: ", at.a, " Error: ", err.Error())
+			continue
+		}
+		entries := p.Entries()
+		if len(entries) != len(at.want) {
+			t.Error("Entries() for: ", at.a, " Expected: ", len(at.want), " Got: ", entriesString(entries))
+			continue
+		}
+		for i, e := range entries {
+			if got := e.Rel() + "|" + e.AriaCurrent() + "|" + e.Label(); got != at.want[i] {
+				t.Error("Entry ", e.Number, " for: ", at.a, " Expected: ", at.want[i], " Got: ", got)
+			}
+		}
+	}
+
+	p, _ := New(Args{Page: 1, Total: 10, Size: 10, Labels: Labels{Nav: "Paginering"}})
+	if got := p.Label(); got != "Paginering" {
+		t.Error("Label() Expected: Paginering Got: ", got)
+	}
+}
+
+func TestAccessibleTemplate(t *testing.T) {
+	tmpl := template.Must(Templates(template.New("test")))
+	tests := []struct {
+		a    Args
+		want []string
+	}{
+		{Args{Max: 5, Pos: 3, Page: 1, Total: 30, Size: 10}, []string{
+			`<nav class="pagination" aria-label="Pagination">`,
+			`<a role="link" aria-disabled="true">First</a>`,
+			`<a role="link" aria-disabled="true">Previous</a>`,
+			`<a href="?page=1" aria-current="page" aria-label="Page 1, current page">1</a>`,
+			`<a rel="next" href="?page=2" aria-label="Page 2">2</a>`,
+			`<a rel="last" href="?page=3">Last</a>`,
+		}},
+		{Args{Page: 50, Total: 1000, Size: 10, Layout: Ellipsis, Boundary: 1, Siblings: 0}, []string{
+			`<a rel="first" href="?page=1">First</a>`,
+			`<a rel="prev" href="?page=49">Previous</a>`,
+			`<span aria-hidden="true">…</span>`,
+			`<a rel="last" href="?page=100" aria-label="Page 100">100</a>`,
+			`<a rel="next" href="?page=51">Next</a>`,
+		}},
+	}
+	for _, tt := range tests {
+		p, err := New(tt.a)
+		if err != nil {
+			t.Error("New() for: ", tt.a, " Error: ", err.Error())
+			continue
+		}
+		var b strings.Builder
+		if err = tmpl.ExecuteTemplate(&b, "pagination/accessible", p); err != nil {
+			t.Error("ExecuteTemplate() for: ", tt.a, " Error: ", err.Error())
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(b.String(), want) {
+				t.Error("ExecuteTemplate() for: ", tt.a, " Expected: ", want, " Got: ", b.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+/*
+Package client follows paginated HTTP APIs. It walks over all pages of a JSON API,
+using a Strategy to find the next page, and decodes the items on every page:
+
+	items, err := client.All[Repo](ctx, "https://api.example.com/repos", &client.Options{
+		Strategy:    client.LinkHeader{},
+		TotalHeader: "X-Total-Count",
+	})
+
+Walk calls a function for every page instead, with a pagination.Pagination if the total is known.
+*/
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/muhlemmer/pagination"
+)
+
+const errBase = "Error in pagination client: "
+
+// DefaultMaxPages is the maximum amount of pages walked if Options.MaxPages is 0.
+const DefaultMaxPages = 1000
+
+var (
+	// ErrMaxPages is returned when the maximum amount of pages is walked and there is still a next page.
+	ErrMaxPages = errors.New(errBase + "maximum amount of pages reached")
+	// ErrPointer is returned when a JSON pointer doesn't point to a value of the expected type.
+	ErrPointer = errors.New(errBase + "JSON pointer mismatch")
+)
+
+// StatusError is returned for a response without a 2xx status code.
+type StatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return errBase + e.URL + ": " + http.StatusText(e.StatusCode) + " (" + strconv.Itoa(e.StatusCode) + ")"
+}
+
+// Options configure a walk over the pages of an API.
+type Options struct {
+	Client   *http.Client // http.DefaultClient if nil
+	Header   http.Header  // Additional request headers, like Authorization (optional)
+	Strategy Strategy     // Finds the next page, LinkHeader if nil
+	MaxPages int          // Maximum amount of pages, DefaultMaxPages if 0, no limit if negative
+
+	Items       string // JSON pointer to the array of items, like "/data", empty for a body which is an array
+	Total       string // JSON pointer to the total amount of items (optional)
+	TotalHeader string // Response header with the total amount of items, like "X-Total-Count" (optional)
+	Size        int    // Page size of the API, the amount of items on the first page if 0
+}
+
+// Page is the response for one page, passed to a Strategy.
+type Page struct {
+	Request  *http.Request
+	Response *http.Response // The body is already read
+	Body     interface{}    // The decoded JSON body, with numbers as json.Number
+	Items    int            // Amount of items on the page
+}
+
+// Walk requests the pages starting at rawURL, decodes their items and calls fn for every page, until there is no next page.
+// The pagination passed to fn is nil if the total is unknown. Page numbers count from the first page walked.
+// The walk stops on the first error, including an error returned by fn and cancellation of ctx.
+func Walk[T any](ctx context.Context, rawURL string, o *Options, fn func(items []T, p *pagination.Pagination) error) error {
+	if o == nil {
+		o = new(Options)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	size := o.Size
+	for n := 1; u != nil; n++ {
+		if max := o.maxPages(); max > 0 && n > max {
+			return ErrMaxPages
+		}
+		page, err := o.get(ctx, u)
+		if err != nil {
+			return err
+		}
+		var items []T
+		if err = decodeItems(page.Body, o.Items, &items); err != nil {
+			return err
+		}
+		page.Items = len(items)
+		if size == 0 {
+			size = len(items)
+		}
+		if err = fn(items, o.pagination(page, n, size)); err != nil {
+			return err
+		}
+		if u, err = o.strategy().Next(page); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// All walks over all pages like Walk and returns all items.
+func All[T any](ctx context.Context, rawURL string, o *Options) (all []T, err error) {
+	err = Walk(ctx, rawURL, o, func(items []T, _ *pagination.Pagination) error {
+		all = append(all, items...)
+		return nil
+	})
+	return
+}
+
+func (o *Options) maxPages() int {
+	if o.MaxPages == 0 {
+		return DefaultMaxPages
+	}
+	return o.MaxPages
+}
+
+func (o *Options) strategy() Strategy {
+	if o.Strategy == nil {
+		return LinkHeader{}
+	}
+	return o.Strategy
+}
+
+// get requests u and decodes the JSON body.
+func (o *Options) get(ctx context.Context, u *url.URL) (*Page, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range o.Header {
+		req.Header[k] = v
+	}
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
+	}
+
+	c := o.Client
+	if c == nil {
+		c = http.DefaultClient
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		io.Copy(io.Discard, resp.Body)
+		return nil, &StatusError{URL: u.String(), StatusCode: resp.StatusCode}
+	}
+
+	p := &Page{Request: req, Response: resp}
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber() // Keep large IDs exact, instead of rounding them to float64
+	if err = dec.Decode(&p.Body); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// pagination returns the pagination of page number n, or nil if the total is unknown or inconsistent.
+func (o *Options) pagination(page *Page, n, size int) *pagination.Pagination {
+	total, ok := o.total(page)
+	if !ok {
+		return nil
+	}
+	p, err := pagination.New(pagination.Args{Page: n, Size: size, Total: total, Records: page.Items})
+	if err != nil {
+		return nil
+	}
+	return p
+}
+
+func (o *Options) total(page *Page) (int, bool) {
+	if o.TotalHeader != "" {
+		if n, err := strconv.Atoi(page.Response.Header.Get(o.TotalHeader)); err == nil {
+			return n, true
+		}
+	}
+	if o.Total != "" {
+		if v, err := Lookup(page.Body, o.Total); err == nil {
+			if n, ok := v.(json.Number); ok {
+				if n, err := strconv.Atoi(n.String()); err == nil {
+					return n, true
+				}
+			}
+		}
+	}
+	return 0, false
+}
+
+// decodeItems decodes the array at the JSON pointer ptr in body into items.
+func decodeItems(body interface{}, ptr string, items interface{}) error {
+	v, err := Lookup(body, ptr)
+	if err != nil {
+		return err
+	}
+	if _, ok := v.([]interface{}); !ok {
+		return ErrPointer
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, items)
+}
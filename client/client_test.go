@@ -0,0 +1,199 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/muhlemmer/pagination"
+)
+
+const total = 23
+
+// api serves the numbers 1 to 23 in pages of 5, in several styles:
+//
+//	/link?page=n    array body, Link header and X-Total-Count header
+//	/cursor?after=n {"data": [...], "meta": {"total": 23, "next": "n"}}
+//	/next?page=n    {"data": [...], "links": {"next": "/next?page=n"}}
+//	/page?page=n    array body, empty after the last page
+func api() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a, err := pagination.FromRequest(r, &pagination.RequestOptions{
+			SizeParam: "-",
+			Args:      pagination.Args{Size: 5, Total: total, URL: r.URL},
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.URL.Path == "/cursor" {
+			after, _ := strconv.Atoi(r.URL.Query().Get("after"))
+			a.Page = after/5 + 1
+		}
+		items := []int{}
+		start, end := a.Range()
+		for n := start + 1; n <= end; n++ {
+			items = append(items, n)
+		}
+		if r.URL.Path == "/page" {
+			json.NewEncoder(w).Encode(items)
+			return
+		}
+		a.Records = len(items)
+		p, err := pagination.New(a)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var body interface{} = items
+		switch r.URL.Path {
+		case "/link":
+			p.SetLinkHeader(w)
+			w.Header().Set("X-Total-Count", strconv.Itoa(total))
+		case "/cursor":
+			meta := map[string]interface{}{"total": total}
+			if p.HasNext() {
+				meta["next"] = strconv.Itoa(end)
+			}
+			body = map[string]interface{}{"data": items, "meta": meta}
+		case "/next":
+			body = map[string]interface{}{"data": items, "links": map[string]interface{}{"next": p.NextURL()}}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(body)
+	}))
+}
+
+func TestAll(t *testing.T) {
+	srv := api()
+	defer srv.Close()
+
+	want := fmt.Sprint([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23})
+	tests := []struct {
+		path string
+		o    *Options
+	}{
+		{"/link", nil},
+		{"/link?page=1", &Options{Strategy: LinkHeader{}, TotalHeader: "X-Total-Count"}},
+		{"/cursor", &Options{Strategy: JSONPointer{Pointer: "/meta/next", Param: "after"}, Items: "/data", Total: "/meta/total"}},
+		{"/next", &Options{Strategy: JSONPointer{Pointer: "/links/next"}, Items: "/data"}},
+		{"/page", &Options{Strategy: PageIncrement{}}},
+		{"/page", &Options{Strategy: PageIncrement{Size: 5}}},
+	}
+	for _, ct := range tests {
+		got, err := All[int](context.Background(), srv.URL+ct.path, ct.o)
+		if err != nil {
+			t.Error("All() for: ", ct.path, " Error: ", err.Error())
+			continue
+		}
+		if fmt.Sprint(got) != want {
+			t.Error("All() for: ", ct.path, " Expected: ", want, " Got: ", got)
+		}
+	}
+}
+
+func TestWalk(t *testing.T) {
+	srv := api()
+	defer srv.Close()
+
+	var pages []string
+	err := Walk(context.Background(), srv.URL+"/link", &Options{TotalHeader: "X-Total-Count"}, func(items []int, p *pagination.Pagination) error {
+		if p == nil {
+			return errors.New("no pagination")
+		}
+		pages = append(pages, fmt.Sprint(p.Page(), "/", p.Pages(), ":", p.Records()))
+		return nil
+	})
+	if want := "[1/5:5 2/5:5 3/5:5 4/5:5 5/5:3]"; err != nil || fmt.Sprint(pages) != want {
+		t.Error("Walk() Expected: ", want, " Got: ", pages, err)
+	}
+
+	// Without a total there is no pagination.
+	err = Walk(context.Background(), srv.URL+"/link", nil, func(items []int, p *pagination.Pagination) error {
+		if p != nil {
+			return errors.New("unexpected pagination")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Error("Walk() Error: ", err.Error())
+	}
+
+	errStop := errors.New("stop")
+	var n int
+	err = Walk(context.Background(), srv.URL+"/link", nil, func(items []int, p *pagination.Pagination) error {
+		if n++; n == 2 {
+			return errStop
+		}
+		return nil
+	})
+	if err != errStop || n != 2 {
+		t.Error("Walk() Expected: ", errStop, " after 2 pages Got: ", err, n)
+	}
+}
+
+func TestWalkErr(t *testing.T) {
+	srv := api()
+	defer srv.Close()
+
+	if _, err := All[int](context.Background(), srv.URL+"/link", &Options{MaxPages: 3}); err != ErrMaxPages {
+		t.Error("All() Expected: ", ErrMaxPages, " Got: ", err)
+	}
+	if _, err := All[int](context.Background(), srv.URL+"/link", &Options{MaxPages: 5}); err != nil {
+		t.Error("All() Expected: no error Got: ", err)
+	}
+
+	var se *StatusError
+	if _, err := All[int](context.Background(), srv.URL+"/missing", nil); !errors.As(err, &se) || se.StatusCode != http.StatusNotFound {
+		t.Error("All() Expected: ", http.StatusNotFound, " Got: ", err)
+	}
+	if _, err := All[int](context.Background(), srv.URL+"/cursor", &Options{Items: "/items"}); err != ErrPointer {
+		t.Error("All() Expected: ", ErrPointer, " Got: ", err)
+	}
+	if _, err := All[string](context.Background(), srv.URL+"/link", nil); err == nil {
+		t.Error("All() Expected: decode error Got: nil")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := Walk(ctx, srv.URL+"/link", nil, func(items []int, p *pagination.Pagination) error {
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Error("Walk() Expected: ", context.Canceled, " Got: ", err)
+	}
+}
+
+func TestAllLargeIDs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"data": [1234567890123456789, 9007199254740993], "meta": {"total": 2}}`)
+	}))
+	defer srv.Close()
+
+	var total int
+	err := Walk(context.Background(), srv.URL, &Options{Items: "/data", Total: "/meta/total"}, func(items []int64, p *pagination.Pagination) error {
+		if p != nil {
+			total = p.Total()
+		}
+		if want := "[1234567890123456789 9007199254740993]"; fmt.Sprint(items) != want {
+			t.Error("Walk() Expected: ", want, " Got: ", items)
+		}
+		return nil
+	})
+	if err != nil || total != 2 {
+		t.Error("Walk() Expected: total 2 Got: ", total, err)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package client
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Lookup returns the value in a decoded JSON document v, at the RFC 6901 JSON pointer ptr, like "/meta/total".
+// An empty pointer refers to the whole document. ErrPointer is returned if there is no such value.
+func Lookup(v interface{}, ptr string) (interface{}, error) {
+	if ptr == "" {
+		return v, nil
+	}
+	if ptr[0] != '/' {
+		return nil, ErrPointer
+	}
+	for _, tok := range strings.Split(ptr[1:], "/") {
+		tok = strings.ReplaceAll(strings.ReplaceAll(tok, "~1", "/"), "~0", "~")
+		switch t := v.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if v, ok = t[tok]; !ok {
+				return nil, ErrPointer
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(tok)
+			if err != nil || i < 0 || i >= len(t) {
+				return nil, ErrPointer
+			}
+			v = t[i]
+		default:
+			return nil, ErrPointer
+		}
+	}
+	return v, nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	var doc interface{}
+	json.Unmarshal([]byte(`{"meta": {"total": 23, "a/b": "slash", "m~n": "tilde"}, "data": [1, {"id": "x"}], "": "empty"}`), &doc)
+
+	tests := []struct {
+		ptr  string
+		want string
+	}{
+		{"/meta/total", "23"},
+		{"/meta/a~1b", "slash"},
+		{"/meta/m~0n", "tilde"},
+		{"/data/1/id", "x"},
+		{"/", "empty"},
+		{"/data/2", "<error>"},
+		{"/data/-1", "<error>"},
+		{"/data/x", "<error>"},
+		{"/meta/total/x", "<error>"},
+		{"/missing", "<error>"},
+		{"meta", "<error>"},
+	}
+	for _, lt := range tests {
+		v, err := Lookup(doc, lt.ptr)
+		got := fmt.Sprint(v)
+		if err != nil {
+			got = "<error>"
+		}
+		if got != lt.want {
+			t.Error("Lookup() for: ", lt.ptr, " Expected: ", lt.want, " Got: ", got)
+		}
+	}
+	if v, err := Lookup(doc, ""); err != nil || v == nil {
+		t.Error("Lookup() for empty pointer Expected: document Got: ", v, err)
+	}
+}
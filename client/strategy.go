@@ -0,0 +1,95 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package client
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/muhlemmer/pagination"
+)
+
+// Strategy finds the next page of an API.
+type Strategy interface {
+	// Next returns the URL of the page after page, or nil if it is the last page.
+	Next(page *Page) (*url.URL, error)
+}
+
+// LinkHeader follows the "next" link of an RFC 8288 Link header, like GitHub's API.
+type LinkHeader struct{}
+
+// Next implements Strategy.
+func (LinkHeader) Next(page *Page) (*url.URL, error) {
+	links, err := pagination.LinksFromHeader(page.Response.Header)
+	if err != nil {
+		return nil, err
+	}
+	l, ok := links.Rel("next")
+	if !ok || l.URL == "" {
+		return nil, nil
+	}
+	return page.Request.URL.Parse(l.URL)
+}
+
+// JSONPointer follows a next page URL or cursor in the body, located by a JSON pointer like "/links/next" or "/meta/next_cursor".
+// The last page has no value, a null value or an empty string at the pointer.
+type JSONPointer struct {
+	Pointer string // JSON pointer to the next URL or cursor
+	Param   string // Query parameter for the cursor, empty if the value is a URL
+}
+
+// Next implements Strategy.
+func (s JSONPointer) Next(page *Page) (*url.URL, error) {
+	v, err := Lookup(page.Body, s.Pointer)
+	if err != nil || v == nil {
+		return nil, nil // The pointer is absent or null on the last page
+	}
+	next, ok := v.(string)
+	if !ok {
+		return nil, ErrPointer
+	}
+	if next == "" {
+		return nil, nil
+	}
+	if s.Param == "" {
+		return page.Request.URL.Parse(next)
+	}
+	return setParam(page.Request.URL, s.Param, next), nil
+}
+
+// PageIncrement increments a page number parameter, until a page has no items,
+// or less than Size items if Size is set.
+type PageIncrement struct {
+	Param string // Page parameter, "page" if empty
+	Size  int    // Page size, to stop at a page which is not full without requesting an empty one (optional)
+}
+
+// Next implements Strategy.
+func (s PageIncrement) Next(page *Page) (*url.URL, error) {
+	if page.Items == 0 || page.Items < s.Size {
+		return nil, nil
+	}
+	param := s.Param
+	if param == "" {
+		param = "page"
+	}
+	n := 1
+	if v := page.Request.URL.Query().Get(param); v != "" {
+		var err error
+		if n, err = strconv.Atoi(v); err != nil {
+			return nil, &pagination.ParamError{Param: param, Value: v, Err: pagination.ErrParamSyntax}
+		}
+	}
+	return setParam(page.Request.URL, param, strconv.Itoa(n+1)), nil
+}
+
+// setParam returns a copy of u, with the query parameter set to value.
+func setParam(u *url.URL, param, value string) *url.URL {
+	c := *u
+	q := c.Query()
+	q.Set(param, value)
+	c.RawQuery = q.Encode()
+	return &c
+}
@@ -0,0 +1,102 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// Codec encodes tokens into compact, URL-safe strings and decodes them again.
+// The zero value produces unsigned tokens which never expire, like Token.String.
+//
+// With a Key, tokens are signed with HMAC-SHA256, so that clients can't forge them.
+// Note that signing doesn't encrypt: the key values can still be read by decoding the string.
+// With a TTL, tokens expire after the given duration and tokens without an expiry time are rejected.
+// Expiry is only enforced for signed tokens, as a client can change or drop the expiry time of an unsigned one.
+type Codec struct {
+	Key []byte        // HMAC key, nil for unsigned tokens
+	TTL time.Duration // Lifetime of a token, 0 for no expiry
+
+	now func() time.Time // Overridden in tests
+}
+
+// payload is the serialized form of a token.
+type payload struct {
+	*Token
+	Expires int64 `json:"e,omitempty"` // Unix time
+}
+
+func (c *Codec) time() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
+
+// Encode serializes t into a string.
+func (c *Codec) Encode(t *Token) string {
+	p := payload{Token: t}
+	if c.TTL > 0 {
+		p.Expires = c.time().Add(c.TTL).Unix()
+	}
+	b, _ := json.Marshal(p) // Can't fail on strings, ints and bools
+	s := base64.RawURLEncoding.EncodeToString(b)
+	if c.Key == nil {
+		return s
+	}
+	return s + "." + base64.RawURLEncoding.EncodeToString(c.sign(s))
+}
+
+// Decode parses a string created by Encode.
+// ErrToken is returned for malformed input, ErrTokenSignature for a missing or invalid signature
+// and ErrTokenExpired for an expired token, or a token without expiry time if the Codec has a TTL.
+func (c *Codec) Decode(s string) (*Token, error) {
+	s, sig, signed := strings.Cut(s, ".")
+	if signed != (c.Key != nil) {
+		return nil, ErrTokenSignature
+	}
+	if signed {
+		mac, err := base64.RawURLEncoding.DecodeString(sig)
+		if err != nil || !hmac.Equal(mac, c.sign(s)) {
+			return nil, ErrTokenSignature
+		}
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrToken
+	}
+	p := payload{Token: new(Token)}
+	if err = json.Unmarshal(b, &p); err != nil || len(p.Keys) == 0 {
+		return nil, ErrToken
+	}
+	if c.TTL > 0 && p.Expires == 0 || p.Expires != 0 && c.time().Unix() > p.Expires {
+		return nil, ErrTokenExpired
+	}
+	return p.Token, nil
+}
+
+func (c *Codec) sign(s string) []byte {
+	h := hmac.New(sha256.New, c.Key)
+	h.Write([]byte(s))
+	return h.Sum(nil)
+}
+
+// FilterHash returns a short hash of the filter parameters of a query, for use in Token.Filter.
+// Comparing it to the hash of the current filter detects a cursor which is used with a different filter.
+func FilterHash(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write([]byte(p))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
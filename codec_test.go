@@ -0,0 +1,109 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func fixedNow(t time.Time) func() time.Time {
+	return func() time.Time { return t }
+}
+
+var codecTokens = []*Token{
+	{Keys: []string{"2018-05-03", "42"}, Size: 10},
+	{Keys: []string{"ü/?&=.", ""}, Backward: true, Size: 25, Filter: FilterHash("q", "golang")},
+}
+
+func TestCodec(t *testing.T) {
+	now := time.Date(2018, 5, 3, 12, 0, 0, 0, time.UTC)
+	codecs := []*Codec{
+		{},
+		{Key: []byte("secret")},
+		{Key: []byte("secret"), TTL: time.Hour, now: fixedNow(now)},
+		{TTL: time.Hour, now: fixedNow(now)},
+	}
+	for _, c := range codecs {
+		for _, want := range codecTokens {
+			s := c.Encode(want)
+			if strings.ContainsAny(s, "+/=?&") {
+				t.Error("Encode() not URL-safe: ", s)
+			}
+			got, err := c.Decode(s)
+			if err != nil {
+				t.Error("Decode() for: ", s, " Error: ", err)
+				continue
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Error("Decode() Expected: ", want, " Got: ", got)
+			}
+		}
+	}
+}
+
+func TestCodecErrors(t *testing.T) {
+	now := time.Date(2018, 5, 3, 12, 0, 0, 0, time.UTC)
+	signed := &Codec{Key: []byte("secret"), TTL: time.Hour, now: fixedNow(now)}
+	other := &Codec{Key: []byte("other"), TTL: time.Hour, now: fixedNow(now)}
+	unsigned := new(Codec)
+	later := &Codec{Key: []byte("secret"), now: fixedNow(now.Add(2 * time.Hour))}
+	noTTL := &Codec{Key: []byte("secret")}
+	unsignedTTL := &Codec{TTL: time.Hour, now: fixedNow(now)}
+
+	s := signed.Encode(codecTokens[0])
+	payload, sig, _ := strings.Cut(s, ".")
+	forged := unsigned.Encode(&Token{Keys: []string{"0"}, Size: 1000})
+
+	tests := []struct {
+		c    *Codec
+		s    string
+		want error
+	}{
+		{signed, "", ErrTokenSignature},
+		{signed, payload, ErrTokenSignature},
+		{signed, forged + "." + sig, ErrTokenSignature},
+		{signed, payload + "." + sig[1:], ErrTokenSignature},
+		{signed, payload + ".!!", ErrTokenSignature},
+		{other, s, ErrTokenSignature},
+		{unsigned, s, ErrTokenSignature},
+		{later, s, ErrTokenExpired},
+		{signed, noTTL.Encode(codecTokens[0]), ErrTokenExpired},
+		{unsignedTTL, unsigned.Encode(codecTokens[0]), ErrTokenExpired},
+		{unsigned, "!!", ErrToken},
+		{unsigned, "e30", ErrToken}, // {}
+	}
+	for _, tt := range tests {
+		if _, err := tt.c.Decode(tt.s); err != tt.want {
+			t.Error("Decode() for: ", tt.s, " Expected: ", tt.want, " Got: ", err)
+		}
+	}
+}
+
+func TestCursorCodec(t *testing.T) {
+	codec := &Codec{Key: []byte("secret")}
+	c, err := NewCursor(CursorArgs{First: first, Last: last, Records: 10, Size: 10, HasMore: true, Codec: codec})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := codec.Decode(c.Next())
+	if err != nil {
+		t.Fatal("Decode() for: ", c.Next(), " Error: ", err)
+	}
+	if want := c.NextToken(); !reflect.DeepEqual(got, want) {
+		t.Error("Decode() Expected: ", want, " Got: ", got)
+	}
+}
+
+func TestFilterHash(t *testing.T) {
+	if FilterHash("a", "bc") == FilterHash("ab", "c") {
+		t.Error("FilterHash() collision on part boundaries")
+	}
+	if h := FilterHash("q", "golang"); len(h) != 16 || h != FilterHash("q", "golang") {
+		t.Error("FilterHash() unstable or wrong length: ", h)
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+// Pager is implemented by both Pagination and Cursor, so a template can render either one.
+type Pager interface {
+	HasPrev() bool     // Is there a previous page
+	HasNext() bool     // Is there a next page
+	PrevParam() string // Query parameter value for the previous page, empty if there is none
+	NextParam() string // Query parameter value for the next page, empty if there is none
+	Records() int      // Current amount of records
+	Size() int         // Records per page
+}
+
+var (
+	_ Pager = (*Pagination)(nil)
+	_ Pager = (*Cursor)(nil)
+)
+
+// Token is the decoded form of a cursor. It points to a row in a keyset ordered result set.
+type Token struct {
+	Keys     []string `json:"k"`           // Sort key values of the row the cursor points at
+	Backward bool     `json:"b,omitempty"` // Fetch the rows before Keys, instead of after
+	Size     int      `json:"s,omitempty"` // Page size, 0 if unknown
+	Filter   string   `json:"f,omitempty"` // Optional hash of the query filter, see FilterHash
+}
+
+// String encodes the token into a compact, URL-safe string. The string is not signed, use a Codec for that.
+func (t *Token) String() string {
+	return new(Codec).Encode(t)
+}
+
+// ParseToken decodes a string created by Token.String.
+// ErrToken is returned for malformed input.
+func ParseToken(s string) (*Token, error) {
+	t, err := new(Codec).Decode(s)
+	if err == ErrTokenSignature {
+		err = ErrToken
+	}
+	return t, err
+}
+
+// CursorArgs contains the arguments for constructing a NewCursor pagination object.
+//
+// Query Size+1 rows to determine HasMore cheaply. When the current page was fetched backwards,
+// the rows are expected in display order: First and Last are the keys of the first and last row as shown.
+type CursorArgs struct {
+	Token   *Token   //Token the current page was fetched with, nil for the first page
+	First   []string //Sort key values of the first row on the current page
+	Last    []string //Sort key values of the last row on the current page
+	Records int      //Current results
+	Size    int      //Records per page
+	HasMore bool     //More rows exist beyond the current page, in the direction it was fetched
+	Filter  string   //Hash of the query filter, copied into the tokens (optional)
+	Codec   *Codec   //Encodes the tokens returned by Prev and Next, unsigned if nil
+}
+
+// check does a sanity check on provided values.
+// All violated constraints are reported at once in a *ValidationError.
+func (a *CursorArgs) check() error {
+	v := new(ValidationError)
+	if a.Size <= 0 {
+		v.add("Size", a.Size, "Size > 0", ErrSize)
+	}
+	if a.Records < 0 {
+		v.add("Records", a.Records, "Records >= 0", ErrRecords)
+	}
+	if a.Records > a.Size {
+		v.add("Records", a.Records, "Records <= Size", ErrRecordsSize)
+	}
+	if a.Records > 0 && len(a.First) == 0 {
+		v.add("First", len(a.First), "len(First) > 0", ErrKeys)
+	}
+	if a.Records > 0 && len(a.Last) == 0 {
+		v.add("Last", len(a.Last), "len(Last) > 0", ErrKeys)
+	}
+	return v.err()
+}
+
+// Cursor holds the cursor (keyset) pagination methods. Like Pagination, this object can be passed directly to a html/template.
+//
+// Cursor pagination doesn't need a total count or an offset, which makes it suitable for large tables.
+// The downside is that there are no page numbers: only the previous and next page can be linked to.
+type Cursor struct {
+	args *CursorArgs
+}
+
+// NewCursor creates a new cursor pagination object and returns a pointer to it.
+// Like New, it performs some sanity checks and returns a *ValidationError for bogus values.
+func NewCursor(a CursorArgs) (c *Cursor, err error) {
+	if err = a.check(); err != nil {
+		return
+	}
+	c = &Cursor{args: &a}
+	return
+}
+
+func (c *Cursor) backward() bool {
+	return c.args.Token != nil && c.args.Token.Backward
+}
+
+// PrevToken returns the token pointing to the previous page. It returns nil if there is no previous page.
+func (c *Cursor) PrevToken() *Token {
+	if c.args.Records == 0 {
+		return nil
+	}
+	if c.backward() && !c.args.HasMore || !c.backward() && c.args.Token == nil {
+		return nil
+	}
+	return &Token{Keys: c.args.First, Backward: true, Size: c.args.Size, Filter: c.args.Filter}
+}
+
+// NextToken returns the token pointing to the next page. It returns nil if there is no next page.
+func (c *Cursor) NextToken() *Token {
+	if c.args.Records == 0 || !c.backward() && !c.args.HasMore {
+		return nil
+	}
+	return &Token{Keys: c.args.Last, Size: c.args.Size, Filter: c.args.Filter}
+}
+
+// HasPrev returns true if there is a previous page.
+func (c *Cursor) HasPrev() bool {
+	return c.PrevToken() != nil
+}
+
+// HasNext returns true if there is a next page.
+func (c *Cursor) HasNext() bool {
+	return c.NextToken() != nil
+}
+
+// Prev returns the token of the previous page, encoded by CursorArgs.Codec. It returns an empty string if there is no previous page.
+func (c *Cursor) Prev() string {
+	return c.encode(c.PrevToken())
+}
+
+// Next returns the token of the next page, encoded by CursorArgs.Codec. It returns an empty string if there is no next page.
+func (c *Cursor) Next() string {
+	return c.encode(c.NextToken())
+}
+
+// PrevParam is an alias for Prev, to implement Pager.
+func (c *Cursor) PrevParam() string {
+	return c.Prev()
+}
+
+// NextParam is an alias for Next, to implement Pager.
+func (c *Cursor) NextParam() string {
+	return c.Next()
+}
+
+// Records is a getter wrapper for CursorArgs.Records. It returns the current amount of records.
+func (c *Cursor) Records() int {
+	return c.args.Records
+}
+
+// Size is a getter wrapper for CursorArgs.Size. It returns the page size.
+func (c *Cursor) Size() int {
+	return c.args.Size
+}
+
+func (c *Cursor) encode(t *Token) string {
+	switch {
+	case t == nil:
+		return ""
+	case c.args.Codec == nil:
+		return t.String()
+	default:
+		return c.args.Codec.Encode(t)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var (
+	first = []string{"2018-05-01", "17"}
+	last  = []string{"2018-05-03", "42"}
+)
+
+var cursorTests = []struct {
+	a          CursorArgs
+	prev, next *Token
+}{
+	// First page, more to come.
+	{CursorArgs{nil, first, last, 10, 10, true, "", nil}, nil, &Token{Keys: last, Size: 10}},
+	// First and only page.
+	{CursorArgs{nil, first, last, 5, 10, false, "", nil}, nil, nil},
+	// Forward, in the middle.
+	{CursorArgs{&Token{Keys: []string{"x"}}, first, last, 10, 10, true, "", nil}, &Token{Keys: first, Backward: true, Size: 10}, &Token{Keys: last, Size: 10}},
+	// Forward, last page.
+	{CursorArgs{&Token{Keys: []string{"x"}}, first, last, 3, 10, false, "", nil}, &Token{Keys: first, Backward: true, Size: 10}, nil},
+	// Backward, in the middle.
+	{CursorArgs{&Token{Keys: []string{"x"}, Backward: true}, first, last, 10, 10, true, "", nil}, &Token{Keys: first, Backward: true, Size: 10}, &Token{Keys: last, Size: 10}},
+	// Backward, reached the first page.
+	{CursorArgs{&Token{Keys: []string{"x"}, Backward: true}, first, last, 10, 10, false, "", nil}, nil, &Token{Keys: last, Size: 10}},
+	// Empty result set.
+	{CursorArgs{nil, nil, nil, 0, 10, false, "", nil}, nil, nil},
+	// Filter is carried over.
+	{CursorArgs{nil, first, last, 10, 10, true, "abc", nil}, nil, &Token{Keys: last, Size: 10, Filter: "abc"}},
+}
+
+func TestCursor(t *testing.T) {
+	for _, ct := range cursorTests {
+		c, err := NewCursor(ct.a)
+		if err != nil {
+			t.Error("NewCursor() for: ", ct.a, " Error: ", err.Error())
+			continue
+		}
+		if got := c.PrevToken(); !reflect.DeepEqual(got, ct.prev) {
+			t.Error("PrevToken() for: ", ct.a, " Expected: ", ct.prev, " Got: ", got)
+		}
+		if got := c.NextToken(); !reflect.DeepEqual(got, ct.next) {
+			t.Error("NextToken() for: ", ct.a, " Expected: ", ct.next, " Got: ", got)
+		}
+		if c.HasPrev() != (ct.prev != nil) {
+			t.Error("HasPrev() for: ", ct.a, " Expected: ", ct.prev != nil, " Got: ", c.HasPrev())
+		}
+		if c.HasNext() != (ct.next != nil) {
+			t.Error("HasNext() for: ", ct.a, " Expected: ", ct.next != nil, " Got: ", c.HasNext())
+		}
+		if c.Prev() != ct.prev.stringOrEmpty() {
+			t.Error("Prev() for: ", ct.a, " Expected: ", ct.prev.stringOrEmpty(), " Got: ", c.Prev())
+		}
+		if c.Next() != ct.next.stringOrEmpty() {
+			t.Error("Next() for: ", ct.a, " Expected: ", ct.next.stringOrEmpty(), " Got: ", c.Next())
+		}
+		if c.Records() != ct.a.Records || c.Size() != ct.a.Size {
+			t.Error("Records(), Size() for: ", ct.a, " Got: ", c.Records(), c.Size())
+		}
+	}
+}
+
+var errCursorTests = []struct {
+	a    CursorArgs
+	want error
+}{
+	{CursorArgs{Size: 0}, ErrSize},
+	{CursorArgs{Records: -1, Size: 10}, ErrRecords},
+	{CursorArgs{First: first, Last: last, Records: 11, Size: 10}, ErrRecordsSize},
+	{CursorArgs{Last: last, Records: 5, Size: 10}, ErrKeys},
+	{CursorArgs{First: first, Records: 5, Size: 10}, ErrKeys},
+}
+
+func TestErrCursor(t *testing.T) {
+	for _, et := range errCursorTests {
+		if _, err := NewCursor(et.a); !errors.Is(err, et.want) {
+			t.Error("For: ", et.a, "; Expected: ", et.want, "; Got: ", err)
+		}
+	}
+}
+
+func TestToken(t *testing.T) {
+	tokens := []*Token{
+		{Keys: last},
+		{Keys: []string{"ü/?&=", ""}, Backward: true, Size: 25, Filter: FilterHash("q", "golang")},
+	}
+	for _, want := range tokens {
+		got, err := ParseToken(want.String())
+		if err != nil {
+			t.Error("ParseToken() for: ", want, " Error: ", err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Error("ParseToken() Expected: ", want, " Got: ", got)
+		}
+	}
+
+	for _, s := range []string{"", "!!", "bnVsbA", "e30"} { // "null", "{}"
+		if _, err := ParseToken(s); err != ErrToken {
+			t.Error("ParseToken() for: ", s, " Expected: ", ErrToken, " Got: ", err)
+		}
+	}
+}
+
+func TestPager(t *testing.T) {
+	p, _ := New(Args{Max: 5, Pos: 3, Page: 1, Records: 10, Total: 100, Size: 10})
+	c, _ := NewCursor(CursorArgs{First: first, Last: last, Records: 10, Size: 10, HasMore: true})
+
+	for _, pg := range []Pager{p, c} {
+		if pg.HasPrev() || pg.PrevParam() != "" {
+			t.Errorf("%T: unexpected previous page %q", pg, pg.PrevParam())
+		}
+		if !pg.HasNext() || pg.NextParam() == "" {
+			t.Errorf("%T: expected a next page", pg)
+		}
+	}
+	if p.NextParam() != "2" {
+		t.Error("NextParam() Expected: 2 Got: ", p.NextParam())
+	}
+}
+
+// stringOrEmpty is like Cursor.Prev and Cursor.Next for a nil Codec.
+func (t *Token) stringOrEmpty() string {
+	if t == nil {
+		return ""
+	}
+	return t.String()
+}
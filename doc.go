@@ -12,16 +12,36 @@ Usage:
 			Size:    5,    //Records per page
 	}
 
-Naturally, "Pos" should always be smaller than "Max".
+The following constraints apply, each with its own error:
+
+	Max >= 0                         ErrMax
+	1 <= Pos <= Max (if Max > 0)     ErrPos, unless the Layout ignores Pos
+	Boundary >= 0                    ErrBoundary
+	Siblings >= 0                    ErrSiblings
+	Jumps >= 0                       ErrJumps
+	Size > 0                         ErrSize
+	Page >= 1                        ErrPageMin
+	Page <= Pages (or Page == 1)     ErrPageNo
+	Total >= 0                       ErrTotal
+	Records >= 0                     ErrRecords
+	Records <= Size                  ErrRecordsSize
+	Records <= Total                 ErrRecordsTotal
+
+Naturally, "Pos" should always be smaller than "Max". A "Max" of 0 results in no entries at all, in which case "Pos" is ignored.
 "Page" should always be smaller than (Total / Size = Pages), rounded up.
 "Records" can never be bigger than "Size" and "Total".
 "Records" is usually equal to Size, but can be smaller on the last page in practice. "Records" value is not used in any calculation and can be omitted if not needed in the template for example statistics.
 
+An empty result set (Total == 0) has 0 pages. Page 1 is still accepted in that case, so the first page of a search without hits is not an error.
+The "Empty" method returns true and "Entries" returns an empty slice, so a template can render a "no results" message instead of a pointless pagination:
+
+	{{if .Empty}}<p>No results</p>{{else}}{{template "pagination" .}}{{end}}
+
 All the constraints are checked during creation of the new pagination object:
 
 	pag, err := pagination.New(a)
 	if err != nil {
-		if err.Error() == pagination.ErrPageNo {
+		if errors.Is(err, pagination.ErrPageNo) {
 			//Invalid request from client, requested page number to high
 			return
 		}
@@ -29,6 +49,84 @@ All the constraints are checked during creation of the new pagination object:
 		return
 	}
 
+The returned error is a *ValidationError, which lists every violated constraint with the offending field, its value and the constraint itself.
+Each violation wraps one of the sentinel errors listed above, so they can be told apart with errors.Is, without comparing strings.
+
+Instead of failing on an out of range page number, for example when a bookmarked page no longer exists because records got deleted, set "Clamp" in the Args.
+The page number is then corrected into 1..Pages. "Clamped" reports if that happened and "RequestedPage" returns the original page number, so the caller can decide to redirect to the canonical page:
+
+	a.Clamp = true
+	pag, err := pagination.New(a)
+	...
+	if pag.Clamped() {
+		http.Redirect(w, r, pag.URL(pag.Page()), http.StatusFound)
+		return
+	}
+
+"FromRequest" parses the page number and size from the query string or form values of a request, into a copy of the Args in the "RequestOptions".
+Bad parameters result in a *ParamError, wrapping one of the ErrParam* errors:
+
+	a, err := pagination.FromRequest(r, &pagination.RequestOptions{
+		MaxSize: 100,
+		Args:    pagination.Args{Max: 9, Pos: 3, Size: 10},
+	})
+
+"Middleware" does this for every request and stores the Args in the request context. Bad parameters are answered by an ErrorHandler,
+"TextError" or "ProblemError" (RFC 7807 JSON), before the request reaches the handler. Once the handler knows the total, "Finalize" creates the pagination:
+
+	http.Handle("/articles", pagination.Middleware(opts, pagination.ProblemError)(http.HandlerFunc(list)))
+
+	func list(w http.ResponseWriter, r *http.Request) {
+		a, _ := pagination.FromContext(r.Context())
+		articles, total, err := db.Articles(a.Offset(), a.Limit())
+		...
+		pag, err := pagination.Finalize(r.Context(), total, len(articles))
+		if err != nil {
+			pagination.ProblemError(w, r, err) // 400 Bad Request for a page number beyond the last page
+			return
+		}
+		...
+	}
+
+The "Offset", "Limit" and "Range" methods of Args and Pagination take care of the page math for queries and slices.
+"AppendLimit" appends the limit clause to a query, in the syntax of the SQL dialect:
+
+	query, args := pagination.Postgres.AppendLimit("SELECT * FROM articles ORDER BY id", nil, a.Limit(), a.Offset())
+
+For data which is already in memory, "Paginate" does all of it at once. It returns the items on the page and the pagination, with "Total" and "Records" taken from the slice.
+"PaginateFunc" filters and sorts the items first:
+
+	items, pag, err := pagination.PaginateFunc(users, a.Page, a.Size, &a,
+		func(u User) bool { return u.Active },
+		func(x, y User) bool { return x.Name < y.Name },
+	)
+
+Batch jobs can walk over every page with an "Iterator". Without a fetch function it derives the pages from "Total" and "Size",
+with one it calls the function for every page, which returns the amount of records and the current total. The iteration stops on the first error:
+
+	it := pagination.NewIterator(pagination.Args{Size: 1000}, func(offset, limit int) (records, total int, err error) {
+		...
+	})
+	for it.Next() {
+		p := it.Pagination()
+		...
+	}
+	if err := it.Err(); err != nil {
+		...
+	}
+
+With Go 1.23 or newer, "Pages" returns the same iteration as an iter.Seq2, for use with range.
+
+Once the total is known, "FetchAll" fetches all pages concurrently and returns the results in page order.
+The first error cancels the remaining fetches, unless "CollectErrors" is set. A "Limiter", like rate.Limiter from golang.org/x/time/rate, throttles the fetches:
+
+	results, err := pagination.FetchAll(ctx, pagination.Args{Total: total, Size: 100},
+		func(ctx context.Context, p *pagination.Pagination) ([]Item, error) {
+			return api.List(ctx, p.Offset(), p.Limit())
+		},
+		&pagination.FetchOptions{Concurrency: 4, Limiter: rate.NewLimiter(10, 1)},
+	)
+
 Now the Pagination object pointer can be incorporated in the data structure passed to a template
 	view := Page{
 		Articles:   results,
@@ -41,34 +139,172 @@ Now the Pagination object pointer can be incorporated in the data structure pass
 Let's say you use a separate template for pagination, you can call it from a layout template like:
 	{{template "pagination" .Pagination}}
 
-Finally, inside the pagination template simply call the methods where you need them. ".Entries" gives the actual pagination range. Every "Entry" has two fields, "Active" and "Number":
+Finally, inside the pagination template simply call the methods where you need them. ".Entries" gives the actual pagination range. Every "Entry" has four fields, "Active", "Number", "Ellipsis" and "URL":
 
 "Active" is set to boolean true if that Entry represents the current page. False for all others. So a direct test can be done unsing {{if .Active}}.
 
 "Number" is set to the number the pagination entry is referring to.
 
-All the other methods just print a number for statistical puposes.
+"Ellipsis" is set to boolean true if the Entry represents a gap of hidden pages, in which case "Number" is 0. Only some layouts produce gaps, see below.
+
+"URL" is the link to the page. Links keep all query parameters of the base URL in the Args, like search filters and sort order, and only change the page parameter.
+Without a base URL, they are relative links like "?page=2". The same links are returned by the "URL", "PrevURL", "NextURL", "FirstURL" and "LastURL" methods:
+
+	a.URL = r.URL        //Base URL for links, typically the current request URL
+	a.Param = "p"        //Page parameter, "page" by default
+	a.Canonical = true   //Omit the page parameter for page 1
+
+Most other methods just print a number for statistical puposes.
 
 	{{define "pagination"}}
 		<!--This example uses bootstrap pagination classes-->
 		<ul class="pagination">
-		{{- if eq .Prev 0}}
-			<li class="page-item disabled"><a class="page-link" >&lt;&lt;&nbsp;First</a></li>
+		{{- if .HasPrev}}
+			<li class="page-item"><a class="page-link" href="{{.FirstURL}}">{{.Labels.First}}</a></li>
+			<li class="page-item"><a class="page-link" rel="prev" href="{{.PrevURL}}">{{.Labels.Prev}}</a></li>
 		{{- else}}
-			<li class="page-item"><a class="page-link" href=".">&lt;&lt;&nbsp;First</a></li>
-			<li class="page-item"><a class="page-link" rel="prev" href="?page={{.Prev}}">&lt;&nbsp;Previous</a></li>
-		{{end}}
+			<li class="page-item disabled"><a class="page-link">{{.Labels.First}}</a></li>
+			<li class="page-item disabled"><a class="page-link">{{.Labels.Prev}}</a></li>
+		{{- end}}
 		{{- range .Entries}}
-			<li class="page-item{{if .Active}} active{{end}}"><a class="page-link" href="?page={{.Number}}">{{.Number}}</a></li>
+			<li class="page-item{{if .Active}} active{{end}}"><a class="page-link" href="{{.URL}}">{{.Number}}</a></li>
 		{{- end}}
-		{{- if eq .Next 0}}
-			<li class="page-item disabled"><a class="page-link" >last&nbsp;&gt;&gt;</a></li>
+		{{- if .HasNext}}
+			<li class="page-item"><a class="page-link" rel="next" href="{{.NextURL}}">{{.Labels.Next}}</a></li>
+			<li class="page-item"><a class="page-link" href="{{.LastURL}}">{{.Labels.Last}}</a></li>
 		{{- else}}
-			<li class="page-item"><a class="page-link" rel="next" href="?page={{.Next}}">Next&nbsp;&gt;</a></li>
-			<li class="page-item"><a class="page-link" href="/?page={{.Pages}}">Last&nbsp;&gt;&gt;</a></li>
+			<li class="page-item disabled"><a class="page-link">{{.Labels.Next}}</a></li>
+			<li class="page-item disabled"><a class="page-link">{{.Labels.Last}}</a></li>
 		{{- end}}
 		</ul>
-		<p>Page {{.Page}} of {{.Pages}}, showing {{.Records}} record(s) out of {{.Total}} total</p>
+		<p>{{.Summary}}</p>
 	{{end}}
+
+Instead of writing your own, "Templates" adds ready made templates for Bootstrap 4 and 5, Bulma, Tailwind CSS and plain HTML to a template set.
+The texts of the navigation links are set by "Labels" in the Args. "Link" replaces the query parameter links, for example for page numbers in the path:
+
+	t = template.Must(pagination.Templates(t))
+	a.Labels = pagination.Labels{Prev: "« Newer", Next: "Older »"}
+	a.Link = func(n int) string { return "/blog/page/" + strconv.Itoa(n) }
+	...
+	{{template "pagination/bootstrap5" .Pagination}}
+
+"FirstIndex" and "LastIndex" return the 1-based range of records on the current page. "FuncMap" provides more helpers for templates, so they don't need any arithmetic:
+
+	t := template.New("").Funcs(pagination.FuncMap())
+	...
+	Showing {{itemRange .}} of {{humanizeCount .Total}}   <!-- Showing 21–40 of 1.2K -->
+
+For accessible markup of your own, "Label" returns the name of the navigation, and every Entry has an "AriaCurrent", a "Rel" and a "Label" method.
+The "pagination/accessible" template is a reference following the WCAG 2.1 guidance:
+
+	<nav aria-label="{{.Label}}">
+	...
+		<a{{with .Rel}} rel="{{.}}"{{end}} href="{{.URL}}"{{with .AriaCurrent}} aria-current="{{.}}"{{end}} aria-label="{{.Label}}">{{$.FormatNumber .Number}}</a>
+
+The labels, the "Summary" and the numbers of "FormatNumber" are localized by the "Translator" in the Args.
+The built-in templates and the entry labels show the page numbers formatted by "FormatNumber", like "1,234".
+Built-in catalogs are "English" (default), "Dutch", "German" and "Japanese", with plural-aware summaries and digit grouping:
+
+	a.Translator = pagination.German
+	...
+	{{.Summary}}   <!-- Seite 2 von 250, 20 Einträge von insgesamt 4.990 -->
+
+Set "Languages" in the RequestOptions to let "FromRequest" and the "Middleware" select the translator by a "lang" parameter or the Accept-Language header:
+
+	opts := &pagination.RequestOptions{Languages: pagination.Catalogs}
+
+REST APIs can send the same links in an RFC 8288 Link header. Use an absolute base URL for that:
+
+	a.URL = &url.URL{Scheme: "https", Host: r.Host, Path: r.URL.Path, RawQuery: r.URL.RawQuery}
+	pag.SetLinkHeader(w) // Link: <https://...?page=1>; rel="first", <https://...?page=3>; rel="next", ...
+
+Clients can follow them with "ParseLinkHeader" and the "Rel" method of the returned Links.
+The "client" subpackage walks over all pages of an API, following Link headers, next page URLs or cursors in the body, or page numbers.
+
+A *Pagination also implements json.Marshaler, so it can be embedded in an API response directly.
+The "JSON" options in the Args select snake_case or camelCase field names and include the entries and links:
+
+	a.JSON = pagination.JSONOptions{Naming: pagination.CamelCase, Links: true}
+	// {"page":2,"size":10,"total":30,"pages":3,"records":10,"prev":1,"next":3,"hasPrev":true,"hasNext":true,"links":{...}}
+
+Unmarshaling checks the values like "New" does. "MarshalText" and "UnmarshalText" use a query string form instead, like "page=2&records=10&size=10&total=30".
+
+A "Page" bundles the items with their pagination in a complete response document. "NewPage" checks that the amount of items matches "Records".
+The "Envelope" selects the shape: "Plain" ({"items": ..., "pagination": ...}), "JSONAPI" ("data", "meta" and "links") or "HAL" ("_links" and "_embedded"):
+
+	pg, err := pagination.NewPage(articles, pag)
+	...
+	pg.Envelope = pagination.JSONAPI
+	json.NewEncoder(w).Encode(pg)
+
+The arrangement of the entries is determined by the "Layout" in the Args. The built-in layouts are:
+
+	Window        sliding window of Max entries, with the current page at Pos (default)
+	Centered      window of Max entries, with the current page in the middle
+	Ellipsis      Boundary pages at each end and Siblings around the current page, with gaps
+	Decades       like Ellipsis, plus the Jumps (3 by default) nearest multiples of ten: 1 [2] 3 … 10 20 30 … 100
+	Logarithmic   like Ellipsis, plus jumps of 10, 100, 1000 pages etc. for huge result sets
+
+Any other type implementing the Layout interface can be used as well.
+
+For example, the "Ellipsis" layout always shows the first and last pages ("Boundary" pages at each end) and the pages around the current one ("Siblings" on each side), like "1 … 7 8 [9] 10 11 … 100":
+
+	a := pagination.Args{
+		Page:     9,
+		Total:    1000,
+		Size:     10,
+		Layout:   pagination.Ellipsis,
+		Boundary: 1,
+		Siblings: 2,
+	}
+
+A gap is represented by an "Entry" with the "Ellipsis" field set, so the template doesn't need any arithmetic:
+
+	{{- range .Entries}}
+		{{- if .Ellipsis}}
+			<li class="page-item disabled"><span class="page-link">&hellip;</span></li>
+		{{- else}}
+			<li class="page-item{{if .Active}} active{{end}}"><a class="page-link" href="{{.URL}}">{{.Number}}</a></li>
+		{{- end}}
+	{{- end}}
+
+# Cursor pagination
+
+Offset pagination needs a total count and an offset, which can be expensive on large tables.
+"Cursor" implements keyset pagination instead: it takes the sort keys of the first and last row on the current page and produces tokens pointing to the previous and next page.
+Query one more row than the page size, to know if there are more rows:
+
+	c, err := pagination.NewCursor(pagination.CursorArgs{
+		Token:   token,  //Token the page was fetched with, nil for the first page
+		First:   []string{rows[0].Created, rows[0].ID},
+		Last:    []string{rows[n-1].Created, rows[n-1].ID},
+		Records: n,
+		Size:    10,
+		HasMore: len(rows) > 10,
+	})
+
+By default the tokens are only base64 encoded JSON. Set a "Codec" with a "Key" to sign them, so clients can't forge them, and a "TTL" to let them expire.
+The same Codec decodes the token on the next request and returns ErrTokenSignature or ErrTokenExpired for bad tokens:
+
+	codec := &pagination.Codec{Key: secret, TTL: time.Hour}
+	token, err := codec.Decode(r.FormValue("cursor"))
+
+"Keyset" generates the matching SQL: the predicate selecting the rows after (or before) the token, the sort order and the limit.
+Mixed ascending and descending columns are supported:
+
+	ks := pagination.Keyset{
+		Dialect: pagination.Postgres,
+		Columns: []pagination.SortColumn{{Name: "score", Desc: true}, {Name: "id"}},
+	}
+	c, err := ks.Build(token, 10, nil)
+	// c.Where:   (score < $1 OR (score = $1 AND id > $2))
+	// c.OrderBy: score DESC, id ASC
+	// c.Limit:   LIMIT $3
+
+Both "Pagination" and "Cursor" implement the "Pager" interface, so a template using only "HasPrev", "HasNext", "PrevParam" and "NextParam" can render either one:
+
+	{{if .HasPrev}}<a rel="prev" href="?cursor={{.PrevParam}}">Previous</a>{{end}}
+	{{if .HasNext}}<a rel="next" href="?cursor={{.NextParam}}">Next</a>{{end}}
 */
 package pagination
@@ -0,0 +1,107 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ErrNoPagination is returned by Page.MarshalJSON for a Page without a Pagination.
+var ErrNoPagination = errors.New(errBase + "Page without Pagination")
+
+// Envelope is the shape of the JSON document produced by Page.MarshalJSON.
+type Envelope int
+
+// Supported envelopes.
+const (
+	// Plain is {"items": [...], "pagination": {...}}, with the pagination as produced by Pagination.MarshalJSON.
+	Plain Envelope = iota
+	// JSONAPI is the JSON:API top level document: {"data": [...], "meta": {...}, "links": {"self": ..., "first": ..., ...}}.
+	// The pagination is placed in "meta".
+	JSONAPI
+	// HAL is the HAL document: {"_links": {"self": {"href": ...}, ...}, "_embedded": {"items": [...]}, "pagination": {...}}.
+	HAL
+)
+
+// Page bundles the items of the current page with its pagination, for use as an API response.
+// Use NewPage to create one, which guarantees that the amount of items matches the pagination.
+type Page[T any] struct {
+	Items      []T         // Items on the current page
+	Pagination *Pagination // Pagination of the items
+
+	Envelope Envelope // Shape of the JSON document
+	Rel      string   // Name of the embedded items for the HAL envelope, "items" if empty
+}
+
+// NewPage returns a Page with the items and the pagination, in the Plain envelope.
+// A *ValidationError wrapping ErrItems is returned if the amount of items doesn't match p.Records().
+func NewPage[T any](items []T, p *Pagination) (pg *Page[T], err error) {
+	v := new(ValidationError)
+	if len(items) != p.Records() {
+		v.add("Items", len(items), "len(Items) == Records", ErrItems)
+	}
+	if err = v.err(); err != nil {
+		return
+	}
+	pg = &Page[T]{Items: items, Pagination: p}
+	return
+}
+
+// MarshalJSON implements json.Marshaler, in the shape of the Envelope.
+// The naming convention and the optional entries are taken from the JSON options of the pagination.
+// The links are always included, as top level links for JSONAPI and HAL.
+// ErrNoPagination is returned if Pagination is nil.
+func (pg *Page[T]) MarshalJSON() ([]byte, error) {
+	p := pg.Pagination
+	if p == nil {
+		return nil, ErrNoPagination
+	}
+	items := pg.Items
+	if items == nil {
+		items = []T{}
+	}
+	o := &jsonObject{naming: p.args.JSON.Naming}
+
+	switch pg.Envelope {
+	case JSONAPI:
+		o.field("data", items)
+		o.field("meta", p.withoutLinks())
+		o.field("links", pg.links(func(url string) interface{} { return url }))
+	case HAL:
+		o.field("_links", pg.links(func(url string) interface{} { return map[string]string{"href": url} }))
+		rel := pg.Rel
+		if rel == "" {
+			rel = "items"
+		}
+		o.field("_embedded", map[string][]T{rel: items})
+		o.field("pagination", p.withoutLinks())
+	default:
+		o.field("items", items)
+		o.field("pagination", p)
+	}
+	return o.bytes()
+}
+
+// links returns the self, first, prev, next and last links, in that order, with the URLs converted by link.
+func (pg *Page[T]) links(link func(url string) interface{}) json.RawMessage {
+	p := pg.Pagination
+	o := &jsonObject{naming: p.args.JSON.Naming}
+	o.field("self", link(p.URL(p.Page())))
+	for _, l := range p.Links() {
+		o.field(l.Rel, link(l.URL))
+	}
+	b, _ := o.bytes() // Can't fail on strings
+	return b
+}
+
+// withoutLinks returns a copy of p, which doesn't include the links in its JSON.
+func (p *Pagination) withoutLinks() *Pagination {
+	a := *p.args
+	a.JSON.Links = false
+	q := *p
+	q.args = &a
+	return &q
+}
@@ -0,0 +1,83 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+var envelopeTests = []struct {
+	a    Args
+	e    Envelope
+	rel  string
+	want string
+}{
+	{
+		Args{Page: 2, Records: 2, Total: 6, Size: 2}, Plain, "",
+		`{"items":["c","d"],"pagination":{"page":2,"size":2,"total":6,"pages":3,"records":2,"prev":1,"next":3,"has_prev":true,"has_next":true}}`,
+	},
+	{
+		Args{Page: 2, Records: 2, Total: 6, Size: 2}, JSONAPI, "",
+		`{"data":["c","d"],"meta":{"page":2,"size":2,"total":6,"pages":3,"records":2,"prev":1,"next":3,"has_prev":true,"has_next":true},` +
+			`"links":{"self":"?page=2","first":"?page=1","prev":"?page=1","next":"?page=3","last":"?page=3"}}`,
+	},
+	{
+		Args{Page: 3, Records: 2, Total: 6, Size: 2, JSON: JSONOptions{Naming: CamelCase, Links: true}}, HAL, "letters",
+		`{"_links":{"self":{"href":"?page=3"},"first":{"href":"?page=1"},"prev":{"href":"?page=2"},"last":{"href":"?page=3"}},` +
+			`"_embedded":{"letters":["e","f"]},` +
+			`"pagination":{"page":3,"size":2,"total":6,"pages":3,"records":2,"prev":2,"next":0,"hasPrev":true,"hasNext":false}}`,
+	},
+	{
+		Args{Page: 1, Total: 0, Size: 2}, HAL, "",
+		`{"_links":{"self":{"href":"?page=1"}},"_embedded":{"items":[]},` +
+			`"pagination":{"page":1,"size":2,"total":0,"pages":0,"records":0,"prev":0,"next":0,"has_prev":false,"has_next":false}}`,
+	},
+}
+
+func TestPage(t *testing.T) {
+	letters := []string{"a", "b", "c", "d", "e", "f"}
+	for _, et := range envelopeTests {
+		p, err := New(et.a)
+		if err != nil {
+			t.Error("New() for: ", et.a, " Error: ", err.Error())
+			continue
+		}
+		start, end := et.a.Range()
+		pg, err := NewPage(letters[start:end], p)
+		if err != nil {
+			t.Error("NewPage() for: ", et.a, " Error: ", err.Error())
+			continue
+		}
+		pg.Envelope, pg.Rel = et.e, et.rel
+		b, err := json.Marshal(pg)
+		if err != nil {
+			t.Error("Marshal() for: ", et.a, " Error: ", err.Error())
+			continue
+		}
+		if got := string(b); got != et.want {
+			t.Error("Marshal() for: ", et.a, " Expected: ", et.want, " Got: ", got)
+		}
+	}
+}
+
+func TestNewPageErr(t *testing.T) {
+	p, err := New(Args{Page: 1, Records: 2, Total: 6, Size: 2})
+	if err != nil {
+		t.Fatal("New() Error: ", err.Error())
+	}
+	if _, err = NewPage([]int{1, 2, 3}, p); !errors.Is(err, ErrItems) {
+		t.Error("NewPage() Expected: ", ErrItems, " Got: ", err)
+	}
+	if _, err = NewPage[int](nil, p); !errors.Is(err, ErrItems) {
+		t.Error("NewPage() Expected: ", ErrItems, " Got: ", err)
+	}
+
+	// A Page built without NewPage might lack the pagination.
+	if _, err = json.Marshal(&Page[int]{Items: []int{1, 2}}); !errors.Is(err, ErrNoPagination) {
+		t.Error("Marshal() Expected: ", ErrNoPagination, " Got: ", err)
+	}
+}
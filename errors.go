@@ -0,0 +1,127 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+const errBase = "Error in pagination: "
+
+// Sentinel errors, one for every constraint on Args.
+// They are never returned directly by New, but wrapped in a *ValidationError.
+// Use errors.Is to test for them.
+var (
+	// ErrPageNo is reported whenever the Page number is larger than the amount of pages.
+	ErrPageNo = errors.New(errBase + "Page > Pages")
+	// ErrPageMin is reported whenever the Page number is smaller than 1.
+	ErrPageMin = errors.New(errBase + "Page < 1")
+	// ErrMax is reported when Max < 0.
+	ErrMax = errors.New(errBase + "Max < 0")
+	// ErrPos is reported when Pos is outside 1..Max, for a Layout using Pos.
+	ErrPos = errors.New(errBase + "Pos outside 1..Max")
+	// ErrBoundary is reported when Boundary < 0.
+	ErrBoundary = errors.New(errBase + "Boundary < 0")
+	// ErrSiblings is reported when Siblings < 0.
+	ErrSiblings = errors.New(errBase + "Siblings < 0")
+	// ErrJumps is reported when Jumps < 0.
+	ErrJumps = errors.New(errBase + "Jumps < 0")
+	// ErrTotal is reported when Total < 0.
+	ErrTotal = errors.New(errBase + "Total < 0")
+	// ErrRecords is reported when Records < 0.
+	ErrRecords = errors.New(errBase + "Records < 0")
+	// ErrSize is reported when Size <= 0.
+	ErrSize = errors.New(errBase + "Size <= 0")
+	// ErrRecordsSize is reported when Records > Size.
+	ErrRecordsSize = errors.New(errBase + "Records > Size")
+	// ErrRecordsTotal is reported when Records > Total.
+	ErrRecordsTotal = errors.New(errBase + "Records > Total")
+	// ErrKeys is reported when CursorArgs has Records, but no First or Last keys.
+	ErrKeys = errors.New(errBase + "Records > 0 without keys")
+	// ErrItems is reported by NewPage when the amount of items doesn't match Records.
+	ErrItems = errors.New(errBase + "len(Items) != Records")
+)
+
+// Errors returned when decoding a cursor token.
+var (
+	// ErrToken is returned when a cursor token can't be decoded.
+	ErrToken = errors.New(errBase + "malformed cursor token")
+	// ErrTokenSignature is returned when the signature of a cursor token is missing or invalid, which means it was tampered with.
+	ErrTokenSignature = errors.New(errBase + "invalid cursor token signature")
+	// ErrTokenExpired is returned when a cursor token is expired.
+	ErrTokenExpired = errors.New(errBase + "expired cursor token")
+)
+
+// ErrColumns is returned by Keyset.Build if there are no sort columns.
+var ErrColumns = errors.New(errBase + "no sort columns")
+
+// Violation describes a single constraint on Args which is not met.
+type Violation struct {
+	Field      string // Name of the offending Args field
+	Value      int    // The bogus value
+	Constraint string // The constraint which is violated, like "Size > 0"
+	Err        error  // One of the sentinel errors
+}
+
+func (v Violation) Error() string {
+	return errBase + v.Field + " = " + strconv.Itoa(v.Value) + ", want " + v.Constraint
+}
+
+// Unwrap returns the sentinel error, for use with errors.Is.
+func (v Violation) Unwrap() error {
+	return v.Err
+}
+
+// ValidationError is returned by New when one or more constraints on Args are not met.
+// All violations are collected in one pass, use errors.As to inspect them:
+//
+//	var verr *pagination.ValidationError
+//	if errors.As(err, &verr) {
+//		for _, v := range verr.Violations {
+//			log.Println(v.Field, v.Value, v.Constraint)
+//		}
+//	}
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	s := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		s[i] = v.Error()
+	}
+	return strings.Join(s, "; ")
+}
+
+// Is reports whether any of the violations is caused by target.
+// This makes errors.Is(err, ErrPageNo) work on a *ValidationError.
+func (e *ValidationError) Is(target error) bool {
+	for _, v := range e.Violations {
+		if v.Err == target {
+			return true
+		}
+	}
+	return false
+}
+
+// add appends a violation to the list.
+func (e *ValidationError) add(field string, value int, constraint string, err error) {
+	e.Violations = append(e.Violations, Violation{
+		Field:      field,
+		Value:      value,
+		Constraint: constraint,
+		Err:        err,
+	})
+}
+
+// err returns e as an error, or nil if there are no violations.
+func (e *ValidationError) err() error {
+	if len(e.Violations) == 0 {
+		return nil
+	}
+	return e
+}
@@ -4,7 +4,6 @@ import (
 	"html/template"
 	"log"
 	"net/http"
-	"strconv"
 
 	"github.com/muhlemmer/pagination"
 )
@@ -20,12 +19,19 @@ const (
 	paginationPos = 3
 )
 
-var templates = template.Must(template.ParseFiles("template/layout.html", "template/pagination.html"))
-
-func invalidArgument(w http.ResponseWriter) {
-	http.Error(w, "Invalid argument(s)", http.StatusBadRequest)
+var requestOptions = &pagination.RequestOptions{
+	SizeParam: "-", //Fixed page size
+	Languages: pagination.Catalogs,
+	Args: pagination.Args{
+		Max:       paginationMax,
+		Pos:       paginationPos,
+		Size:      pageSize,
+		Canonical: true,
+	},
 }
 
+var templates = template.Must(pagination.Templates(template.Must(template.ParseFiles("template/layout.html"))))
+
 func notFound(w http.ResponseWriter, r *http.Request) {
 	e := "Page not found"
 	log.Fatal(e, "path", r.URL.Path, "host", r.RemoteAddr)
@@ -36,58 +42,26 @@ func serverError(w http.ResponseWriter) {
 	http.Error(w, "Internal server error", http.StatusInternalServerError)
 }
 
-//Extracts the page number from the request arguments
-func pageNumber(r *http.Request) (p int, err error) {
-	if r.FormValue("page") == "" {
-		p = 1
-		return
-	}
-	p, err = strconv.Atoi(r.FormValue("page"))
-	if err != nil {
-		log.Println("Invalid argument. Page: ", r.FormValue("page"), "err", err)
-		return
-	}
-	return
-}
-
 func rootHandler(w http.ResponseWriter, r *http.Request) {
 	if r.URL.Path != "/" {
 		notFound(w, r)
 		return
 	}
 
-	p, err := pageNumber(r)
-	if err != nil {
-		invalidArgument(w)
-		return
-	}
+	//The middleware already parsed the pagination parameters
+	a, _ := pagination.FromContext(r.Context())
 
-	results, err := lipsum.Query(query{p, pageSize})
+	results, err := lipsum.Query(a.Offset(), a.Limit())
 	if err != nil {
 		log.Println("Query error: ", err)
 		serverError(w)
 		return
 	}
 
-	//Here is where we start the pagination parsing
-	a := pagination.Args{
-		Max:     paginationMax,
-		Pos:     paginationPos,
-		Page:    p,
-		Records: len(results),
-		Total:   lipsum.Count(),
-		Size:    pageSize,
-	}
-
-	pag, err := pagination.New(a)
+	pag, err := pagination.Finalize(r.Context(), lipsum.Count(), len(results))
 	if err != nil {
-		if err.Error() == pagination.ErrPageNo {
-			log.Println(err.Error())
-			invalidArgument(w)
-			return
-		}
 		log.Println(err.Error())
-		serverError(w)
+		pagination.TextError(w, r, err)
 		return
 	}
 
@@ -104,7 +78,7 @@ func rootHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func main() {
-	http.HandleFunc("/", rootHandler)
+	http.Handle("/", pagination.Middleware(requestOptions, nil)(http.HandlerFunc(rootHandler)))
 
 	log.Println("Listening...")
 	log.Panic(http.ListenAndServe(":8080", nil).Error())
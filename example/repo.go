@@ -14,15 +14,6 @@ func (r row) Desc() string {
 	return string(r)
 }
 
-type query struct {
-	Page  int
-	Limit int
-}
-
-func (q query) Offset() int {
-	return (q.Page - 1) * q.Limit // (38 - 1) * 4 = 37 * 4 = 148
-}
-
 type db []row
 
 type result struct {
@@ -31,8 +22,11 @@ type result struct {
 }
 
 func (d db) list(offset int, limit int) (res []result, err error) {
-	if offset >= len(d) || offset < 0 {
-		err = errors.New("Db list: limit outside range")
+	if offset < 0 {
+		err = errors.New("Db list: offset outside range")
+		return
+	}
+	if offset >= len(d) { // Like SQL, an offset beyond the end results in an empty set
 		return
 	}
 	if offset+limit > len(d) { // 6 + 4 = 10		7 + 4 = 11		0 + 4 = 0
@@ -50,8 +44,8 @@ func (d db) list(offset int, limit int) (res []result, err error) {
 	return
 }
 
-func (d db) Query(q query) (res []result, err error) {
-	return d.list(q.Offset(), q.Limit)
+func (d db) Query(offset int, limit int) (res []result, err error) {
+	return d.list(offset, limit)
 }
 
 func (d db) Count() int {
@@ -0,0 +1,163 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Limiter limits the rate of fetches by FetchAll. It is implemented by golang.org/x/time/rate.Limiter.
+type Limiter interface {
+	// Wait blocks until the next fetch is allowed, or returns an error if ctx is done first.
+	Wait(ctx context.Context) error
+}
+
+// FetchOptions configure FetchAll.
+type FetchOptions struct {
+	Concurrency   int     // Maximum amount of concurrent fetches, 1 if 0 or less
+	Limiter       Limiter // Called before every fetch (optional)
+	CollectErrors bool    // Fetch all pages and return all errors, instead of cancelling on the first error
+}
+
+// PageError is the error of fetching a single page.
+type PageError struct {
+	Page int // Number of the page
+	Err  error
+}
+
+func (e *PageError) Error() string {
+	return errBase + "page " + strconv.Itoa(e.Page) + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error, for use with errors.Is.
+func (e *PageError) Unwrap() error {
+	return e.Err
+}
+
+// FetchError is returned by FetchAll with FetchOptions.CollectErrors, when one or more pages failed.
+type FetchError struct {
+	Errors []*PageError // Ordered by page number
+}
+
+func (e *FetchError) Error() string {
+	s := make([]string, len(e.Errors))
+	for i, pe := range e.Errors {
+		s[i] = pe.Error()
+	}
+	return strings.Join(s, "; ")
+}
+
+// Is reports whether any of the page errors matches target, using errors.Is.
+func (e *FetchError) Is(target error) bool {
+	for _, pe := range e.Errors {
+		if errors.Is(pe, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// FetchAll fetches all pages of a concurrently, starting at a.Page like NewIterator.
+// Total and Size must be known. The pagination of every page is passed to fetch, its Offset and Limit return the range to fetch.
+//
+// The results are returned in page order, one slice per page.
+// By default, the remaining fetches are cancelled on the first error, which is returned as a *PageError.
+// With FetchOptions.CollectErrors, all pages are fetched and the failed ones are reported in a *FetchError,
+// while the results of the other pages are still returned.
+// The options may be nil.
+func FetchAll[T any](ctx context.Context, a Args, fetch func(ctx context.Context, p *Pagination) ([]T, error), o *FetchOptions) ([][]T, error) {
+	if o == nil {
+		o = new(FetchOptions)
+	}
+	var pages []*Pagination
+	it := NewIterator(a, nil)
+	for it.Next() {
+		pages = append(pages, it.Pagination())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		results = make([][]T, len(pages))
+		errs    []*PageError
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		jobs    = make(chan int)
+	)
+	fail := func(i int, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if o.CollectErrors {
+			errs = append(errs, &PageError{Page: pages[i].Page(), Err: err})
+			return
+		}
+		if len(errs) == 0 {
+			errs = append(errs, &PageError{Page: pages[i].Page(), Err: err})
+			cancel()
+		}
+	}
+
+	n := o.Concurrency
+	if n <= 0 {
+		n = 1
+	}
+	if n > len(pages) {
+		n = len(pages)
+	}
+	for w := 0; w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if ctx.Err() != nil {
+					continue // Cancelled while the job was queued
+				}
+				if o.Limiter != nil {
+					if err := o.Limiter.Wait(ctx); err != nil {
+						fail(i, err)
+						continue
+					}
+				}
+				items, err := fetch(ctx, pages[i])
+				if err != nil {
+					fail(i, err)
+					continue
+				}
+				results[i] = items
+			}
+		}()
+	}
+
+feed:
+	for i := range pages {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	switch {
+	case len(errs) == 0:
+		return results, parent.Err() // Pages may have been skipped, if ctx was cancelled
+	case !o.CollectErrors:
+		return results, errs[0]
+	default:
+		sort.Slice(errs, func(i, j int) bool { return errs[i].Page < errs[j].Page })
+		return results, &FetchError{Errors: errs}
+	}
+}
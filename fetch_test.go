@@ -0,0 +1,136 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeFetcher pages over numbers, failing on the pages in fail.
+type fakeFetcher struct {
+	fail    map[int]bool
+	calls   int32
+	running int32
+	max     int32
+}
+
+var errFake = errors.New("fake fetch error")
+
+func (f *fakeFetcher) fetch(ctx context.Context, p *Pagination) ([]int, error) {
+	atomic.AddInt32(&f.calls, 1)
+	n := atomic.AddInt32(&f.running, 1)
+	defer atomic.AddInt32(&f.running, -1)
+	for {
+		m := atomic.LoadInt32(&f.max)
+		if n <= m || atomic.CompareAndSwapInt32(&f.max, m, n) {
+			break
+		}
+	}
+	// Later pages finish first, to test the ordering.
+	time.Sleep(time.Duration(10-p.Page()) * time.Millisecond)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if f.fail[p.Page()] {
+		return nil, errFake
+	}
+	start, end := p.Range()
+	return numbers[start:end], nil
+}
+
+func TestFetchAll(t *testing.T) {
+	f := new(fakeFetcher)
+	res, err := FetchAll(context.Background(), Args{Total: len(numbers), Size: 5}, f.fetch, &FetchOptions{Concurrency: 3})
+	if err != nil {
+		t.Fatal("FetchAll() Error: ", err.Error())
+	}
+	want := "[[1 2 3 4 5] [6 7 8 9 10] [11 12 13 14 15] [16 17 18 19 20] [21 22 23]]"
+	if got := fmt.Sprint(res); got != want {
+		t.Error("FetchAll() Expected: ", want, " Got: ", got)
+	}
+	if f.calls != 5 || f.max > 3 {
+		t.Error("FetchAll() Expected: 5 calls, max 3 concurrent Got: ", f.calls, f.max)
+	}
+
+	f = new(fakeFetcher)
+	res, err = FetchAll(context.Background(), Args{Page: 4, Total: len(numbers), Size: 5}, f.fetch, nil)
+	if got, want := fmt.Sprint(res), "[[16 17 18 19 20] [21 22 23]]"; err != nil || got != want {
+		t.Error("FetchAll() from page 4 Expected: ", want, " Got: ", got, err)
+	}
+	if f.max != 1 {
+		t.Error("FetchAll() without options Expected: 1 concurrent Got: ", f.max)
+	}
+
+	if _, err = FetchAll(context.Background(), Args{Page: 9, Total: 10, Size: 5}, f.fetch, nil); !errors.Is(err, ErrPageNo) {
+		t.Error("FetchAll() Expected: ", ErrPageNo, " Got: ", err)
+	}
+}
+
+func TestFetchAllErr(t *testing.T) {
+	f := &fakeFetcher{fail: map[int]bool{2: true, 4: true}}
+	_, err := FetchAll(context.Background(), Args{Total: len(numbers), Size: 5}, f.fetch, nil)
+	var pe *PageError
+	if !errors.As(err, &pe) || pe.Page != 2 || !errors.Is(err, errFake) {
+		t.Error("FetchAll() Expected: page 2 ", errFake, " Got: ", err)
+	}
+	if f.calls != 2 {
+		t.Error("FetchAll() Expected: cancel after 2 calls Got: ", f.calls)
+	}
+
+	f = &fakeFetcher{fail: map[int]bool{2: true, 4: true}}
+	res, err := FetchAll(context.Background(), Args{Total: len(numbers), Size: 5}, f.fetch, &FetchOptions{Concurrency: 5, CollectErrors: true})
+	var fe *FetchError
+	if !errors.As(err, &fe) || len(fe.Errors) != 2 || fe.Errors[0].Page != 2 || fe.Errors[1].Page != 4 || !errors.Is(err, errFake) {
+		t.Error("FetchAll() Expected: pages 2 and 4 ", errFake, " Got: ", err)
+	}
+	if got, want := fmt.Sprint(res), "[[1 2 3 4 5] [] [11 12 13 14 15] [] [21 22 23]]"; got != want {
+		t.Error("FetchAll() Expected: ", want, " Got: ", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = FetchAll(ctx, Args{Total: len(numbers), Size: 5}, new(fakeFetcher).fetch, nil); !errors.Is(err, context.Canceled) {
+		t.Error("FetchAll() Expected: ", context.Canceled, " Got: ", err)
+	}
+}
+
+// fakeLimiter counts the calls and fails after max calls.
+type fakeLimiter struct {
+	mu    sync.Mutex
+	calls int
+	max   int
+}
+
+var errLimit = errors.New("rate limit exceeded")
+
+func (l *fakeLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls++
+	if l.calls > l.max {
+		return errLimit
+	}
+	return nil
+}
+
+func TestFetchAllLimiter(t *testing.T) {
+	l := &fakeLimiter{max: 10}
+	if _, err := FetchAll(context.Background(), Args{Total: len(numbers), Size: 5}, new(fakeFetcher).fetch, &FetchOptions{Concurrency: 2, Limiter: l}); err != nil || l.calls != 5 {
+		t.Error("FetchAll() Expected: 5 Wait() calls Got: ", l.calls, err)
+	}
+
+	l = &fakeLimiter{max: 3}
+	f := new(fakeFetcher)
+	_, err := FetchAll(context.Background(), Args{Total: len(numbers), Size: 5}, f.fetch, &FetchOptions{Limiter: l})
+	if !errors.Is(err, errLimit) || f.calls != 3 {
+		t.Error("FetchAll() Expected: ", errLimit, " after 3 calls Got: ", err, f.calls)
+	}
+}
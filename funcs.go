@@ -0,0 +1,106 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import "strconv"
+
+// FirstIndex returns the 1-based index of the first record on the current page, like 21 in "showing 21–40 of 499".
+// It returns 0 if there are no records on the current page.
+func (p *Pagination) FirstIndex() int {
+	start, end := p.Range()
+	if start == end {
+		return 0
+	}
+	return start + 1
+}
+
+// LastIndex returns the 1-based index of the last record on the current page, like 40 in "showing 21–40 of 499".
+// It returns 0 if there are no records on the current page.
+func (p *Pagination) LastIndex() int {
+	start, end := p.Range()
+	if start == end {
+		return 0
+	}
+	return end
+}
+
+// FuncMap returns helper functions for templates. It can be passed to the Funcs method of both html/template and text/template:
+//
+//	pageURL(p, n)      link to page n, see Pagination.URL
+//	itemRange(p)       range of records on the current page, like "21–40"
+//	humanizeCount(n)   short form of a count, like "499", "1.2K" or "35M"
+//	seq(first, last)   the numbers first through last, to range over
+//
+// For example:
+//
+//	t := template.New("").Funcs(pagination.FuncMap())
+//	...
+//	Showing {{itemRange .}} of {{humanizeCount .Total}}
+func FuncMap() map[string]interface{} {
+	return map[string]interface{}{
+		"pageURL":       pageURL,
+		"itemRange":     itemRange,
+		"humanizeCount": humanizeCount,
+		"seq":           seq,
+	}
+}
+
+func pageURL(p *Pagination, n int) string {
+	return p.URL(n)
+}
+
+// itemRange formats FirstIndex and LastIndex, or just one index for a single record.
+func itemRange(p *Pagination) string {
+	first, last := p.FirstIndex(), p.LastIndex()
+	if first == last {
+		return strconv.Itoa(first)
+	}
+	return strconv.Itoa(first) + "–" + strconv.Itoa(last)
+}
+
+// humanizeCount abbreviates n with K, M or B. Below 10 units, one decimal is shown.
+// The number is truncated rather than rounded, so that 999999 is "999K" instead of "1000K".
+func humanizeCount(n int) string {
+	if n < 0 {
+		return "-" + humanizeUint(uint(-n)) // Also right for math.MinInt, of which -n overflows to itself
+	}
+	return humanizeUint(uint(n))
+}
+
+func humanizeUint(n uint) string {
+	for _, u := range []struct {
+		size   uint
+		suffix string
+	}{
+		{1e9, "B"},
+		{1e6, "M"},
+		{1e3, "K"},
+	} {
+		if n < u.size {
+			continue
+		}
+		if whole := n / u.size; whole >= 10 {
+			return strconv.FormatUint(uint64(whole), 10) + u.suffix
+		}
+		s := strconv.FormatUint(uint64(n/u.size), 10)
+		if d := n % u.size / (u.size / 10); d > 0 {
+			s += "." + strconv.FormatUint(uint64(d), 10)
+		}
+		return s + u.suffix
+	}
+	return strconv.FormatUint(uint64(n), 10)
+}
+
+// seq returns the numbers first through last, or an empty slice if last < first.
+func seq(first, last int) []int {
+	if last < first {
+		return []int{}
+	}
+	s := make([]int, 0, last-first+1)
+	for n := first; n <= last; n++ {
+		s = append(s, n)
+	}
+	return s
+}
@@ -0,0 +1,97 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	htmltemplate "html/template"
+	"math"
+	"strings"
+	"testing"
+	texttemplate "text/template"
+)
+
+var indexTests = []struct {
+	a           Args
+	first, last int
+	itemRange   string
+}{
+	{Args{Page: 2, Records: 20, Total: 499, Size: 20}, 21, 40, "21–40"},
+	{Args{Page: 25, Records: 19, Total: 499, Size: 20}, 481, 499, "481–499"},
+	{Args{Page: 3, Total: 41, Size: 20}, 41, 41, "41"},
+	{Args{Page: 1, Total: 0, Size: 20}, 0, 0, "0"},
+}
+
+func TestIndex(t *testing.T) {
+	for _, it := range indexTests {
+		p, err := New(it.a)
+		if err != nil {
+			t.Error("New() for: ", it.a, " Error: ", err.Error())
+			continue
+		}
+		if p.FirstIndex() != it.first || p.LastIndex() != it.last {
+			t.Error("FirstIndex(), LastIndex() for: ", it.a, " Expected: ", it.first, it.last, " Got: ", p.FirstIndex(), p.LastIndex())
+		}
+		if got := itemRange(p); got != it.itemRange {
+			t.Error("itemRange() for: ", it.a, " Expected: ", it.itemRange, " Got: ", got)
+		}
+	}
+}
+
+func TestHumanizeCount(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, "0"},
+		{999, "999"},
+		{1000, "1K"},
+		{1234, "1.2K"},
+		{1999, "1.9K"},
+		{12345, "12K"},
+		{999999, "999K"},
+		{1000000, "1M"},
+		{35000000, "35M"},
+		{1500000000, "1.5B"},
+		{-1500, "-1.5K"},
+		{math.MinInt32, "-2.1B"},
+	}
+	for _, ht := range tests {
+		if got := humanizeCount(ht.n); got != ht.want {
+			t.Error("humanizeCount() for: ", ht.n, " Expected: ", ht.want, " Got: ", got)
+		}
+	}
+	if got := humanizeCount(math.MinInt); !strings.HasPrefix(got, "-") || !strings.HasSuffix(got, "B") {
+		t.Error("humanizeCount() for: ", math.MinInt, " Expected: -…B Got: ", got)
+	}
+}
+
+const funcsTemplate = `Showing {{itemRange .}} of {{humanizeCount .Total}}.
+{{- range seq 1 3}} <a href="{{pageURL $ .}}">{{.}}</a>{{end}}{{range seq 3 1}}never{{end}}`
+
+func TestFuncMap(t *testing.T) {
+	p, err := New(Args{Page: 2, Records: 20, Total: 1234, Size: 20, Param: "p"})
+	if err != nil {
+		t.Fatal("New() Error: ", err.Error())
+	}
+
+	var b strings.Builder
+	ht := htmltemplate.Must(htmltemplate.New("html").Funcs(FuncMap()).Parse(funcsTemplate))
+	if err = ht.Execute(&b, p); err != nil {
+		t.Fatal("html/template Execute() Error: ", err.Error())
+	}
+	want := `Showing 21–40 of 1.2K. <a href="?p=1">1</a> <a href="?p=2">2</a> <a href="?p=3">3</a>`
+	if got := b.String(); got != want {
+		t.Error("html/template Expected: ", want, " Got: ", got)
+	}
+
+	b.Reset()
+	tt := texttemplate.Must(texttemplate.New("text").Funcs(FuncMap()).Parse(funcsTemplate))
+	if err = tt.Execute(&b, p); err != nil {
+		t.Fatal("text/template Execute() Error: ", err.Error())
+	}
+	if got := b.String(); got != want {
+		t.Error("text/template Expected: ", want, " Got: ", got)
+	}
+}
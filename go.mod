@@ -0,0 +1,3 @@
+module github.com/muhlemmer/pagination
+
+go 1.18
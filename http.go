@@ -0,0 +1,217 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Errors wrapped in a *ParamError, for bad request parameters.
+var (
+	// ErrParamSyntax is reported for a parameter which is not a number.
+	ErrParamSyntax = errors.New(errBase + "parameter is not a number")
+	// ErrParamNegative is reported for a negative parameter.
+	ErrParamNegative = errors.New(errBase + "parameter is negative")
+	// ErrParamRange is reported for a parameter out of range, like a page number of 0 or a page size above RequestOptions.MaxSize.
+	ErrParamRange = errors.New(errBase + "parameter out of range")
+	// ErrParamNotAllowed is reported for a page size which is not in RequestOptions.Sizes.
+	ErrParamNotAllowed = errors.New(errBase + "parameter value not allowed")
+	// ErrParamDuplicate is reported for a parameter which is given more than once.
+	ErrParamDuplicate = errors.New(errBase + "duplicate parameter")
+	// ErrParamForm is reported for a parameter which can't be unescaped, like "page=%zz".
+	ErrParamForm = errors.New(errBase + "malformed parameter")
+)
+
+// ParamError is returned by FromRequest for a bad request parameter.
+// Err is one of the ErrParam* errors or, for a cursor, one of the ErrToken* errors.
+type ParamError struct {
+	Param string // Name of the parameter
+	Value string // The bogus value
+	Err   error
+}
+
+func (e *ParamError) Error() string {
+	return e.Err.Error() + ": " + e.Param + "=" + strconv.Quote(e.Value)
+}
+
+// Unwrap returns the underlying error, for use with errors.Is.
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
+// RequestOptions configures FromRequest and CursorFromRequest.
+// The zero value is usable and results in the defaults as documented per field.
+type RequestOptions struct {
+	PageParam   string // Name of the page number parameter, "page" if empty
+	SizeParam   string // Name of the page size parameter, "size" if empty. "-" disables the parameter.
+	CursorParam string // Name of the cursor parameter, "cursor" if empty
+	MinSize     int    // Minimum page size, 1 if 0
+	MaxSize     int    // Maximum page size, no maximum if 0
+	Sizes       []int  // Allowed page sizes, any size if empty
+	Codec       *Codec // Decodes the cursor, as unsigned tokens if nil
+
+	// Languages are the translators by language tag, like Catalogs. If set, FromRequest selects Args.Translator
+	// by the language parameter or the Accept-Language header. Otherwise the translator of Args is kept.
+	Languages map[string]Translator
+	LangParam string // Name of the language parameter, "lang" if empty. "-" disables the parameter.
+
+	// Args are used as a template for the returned Args.
+	// Fields like Max, Pos and Layout are copied, Size is the default page size.
+	Args Args
+}
+
+func (o *RequestOptions) param(name, def string) string {
+	if name == "" {
+		return def
+	}
+	return name
+}
+
+// FromRequest parses the page number and page size from the query string and form values of r.
+// It returns a copy of RequestOptions.Args, with Page and Size set from the parameters.
+// Absent parameters result in page 1 and the default page size.
+// Bad parameters result in a *ParamError. Note that the page number can only be checked against the amount of pages by New.
+// With RequestOptions.Languages, Args.Translator is selected for the language of the request, see RequestOptions.
+func FromRequest(r *http.Request, o *RequestOptions) (a Args, err error) {
+	if o == nil {
+		o = new(RequestOptions)
+	}
+	a = o.Args
+	a.Page = 1
+	name := o.param(o.PageParam, "page")
+	s, err := param(r, name)
+	if err != nil {
+		return
+	}
+	if s != "" {
+		if a.Page, err = atoi(name, s); err != nil {
+			return
+		}
+		if a.Page == 0 {
+			err = &ParamError{name, s, ErrParamRange}
+			return
+		}
+	}
+	if a.Size, err = o.size(r); err != nil {
+		return
+	}
+	t, err := o.language(r)
+	if t != nil {
+		a.Translator = t
+	}
+	return
+}
+
+// CursorFromRequest parses the cursor token and page size from the query string and form values of r.
+// The Token is nil for the first page. Like FromRequest, bad parameters result in a *ParamError.
+func CursorFromRequest(r *http.Request, o *RequestOptions) (a CursorArgs, err error) {
+	if o == nil {
+		o = new(RequestOptions)
+	}
+	name := o.param(o.CursorParam, "cursor")
+	s, err := param(r, name)
+	if err != nil {
+		return
+	}
+	if s != "" {
+		var t *Token
+		if o.Codec == nil {
+			t, err = ParseToken(s)
+		} else {
+			t, err = o.Codec.Decode(s)
+		}
+		if err != nil {
+			err = &ParamError{name, s, err}
+			return
+		}
+		a.Token = t
+	}
+	a.Size, err = o.size(r)
+	return
+}
+
+// size parses and checks the page size parameter.
+func (o *RequestOptions) size(r *http.Request) (size int, err error) {
+	name := o.param(o.SizeParam, "size")
+	if name == "-" {
+		return o.Args.Size, nil
+	}
+	s, err := param(r, name)
+	if err != nil || s == "" {
+		return o.Args.Size, err
+	}
+	if size, err = atoi(name, s); err != nil {
+		return
+	}
+
+	min := o.MinSize
+	if min == 0 {
+		min = 1
+	}
+	if size < min || o.MaxSize > 0 && size > o.MaxSize {
+		return 0, &ParamError{name, s, ErrParamRange}
+	}
+	if len(o.Sizes) == 0 {
+		return
+	}
+	for _, s := range o.Sizes {
+		if s == size {
+			return
+		}
+	}
+	return 0, &ParamError{name, s, ErrParamNotAllowed}
+}
+
+// param returns the value of the named parameter, or an empty string if it is absent.
+func param(r *http.Request, name string) (string, error) {
+	// ParseForm skips malformed pairs, but keeps the others. It only reports them on the first call for a request,
+	// so the query is checked for a malformed pair of this parameter on every call.
+	r.ParseForm()
+	if s, ok := malformed(r.URL.RawQuery, name); ok {
+		return "", &ParamError{name, s, ErrParamForm}
+	}
+	switch v := r.Form[name]; len(v) {
+	case 0:
+		return "", nil
+	case 1:
+		return v[0], nil
+	default:
+		return "", &ParamError{name, v[1], ErrParamDuplicate}
+	}
+}
+
+// malformed returns the raw value of the named parameter in query, if it can't be unescaped.
+func malformed(query, name string) (string, bool) {
+	for query != "" {
+		var pair string
+		pair, query, _ = strings.Cut(query, "&")
+		k, v, _ := strings.Cut(pair, "=")
+		if k, err := url.QueryUnescape(k); err != nil || k != name {
+			continue
+		}
+		if _, err := url.QueryUnescape(v); err != nil {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// atoi parses the value s of the named parameter as a non-negative number.
+func atoi(name, s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	switch {
+	case errors.Is(err, strconv.ErrRange):
+		return 0, &ParamError{name, s, ErrParamRange}
+	case err != nil:
+		return 0, &ParamError{name, s, ErrParamSyntax}
+	case n < 0:
+		return 0, &ParamError{name, s, ErrParamNegative}
+	}
+	return n, nil
+}
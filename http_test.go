@@ -0,0 +1,113 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var requestOptions = &RequestOptions{
+	MaxSize: 100,
+	Args:    Args{Max: 5, Pos: 3, Size: 10},
+}
+
+var fromRequestTests = []struct {
+	query string
+	o     *RequestOptions
+	want  Args
+	err   error
+}{
+	{"", requestOptions, Args{Max: 5, Pos: 3, Page: 1, Size: 10}, nil},
+	{"page=7", requestOptions, Args{Max: 5, Pos: 3, Page: 7, Size: 10}, nil},
+	{"page=7&size=25", requestOptions, Args{Max: 5, Pos: 3, Page: 7, Size: 25}, nil},
+	{"p=3&n=20", &RequestOptions{PageParam: "p", SizeParam: "n"}, Args{Page: 3, Size: 20}, nil},
+	{"page=3&size=20", &RequestOptions{SizeParam: "-", Args: Args{Size: 10}}, Args{Page: 3, Size: 10}, nil},
+	{"size=50", &RequestOptions{Sizes: []int{10, 50, 100}}, Args{Page: 1, Size: 50}, nil},
+	{"page=seven", requestOptions, Args{}, ErrParamSyntax},
+	{"page=", requestOptions, Args{Max: 5, Pos: 3, Page: 1, Size: 10}, nil},
+	{"page=-1", requestOptions, Args{}, ErrParamNegative},
+	{"page=0", requestOptions, Args{}, ErrParamRange},
+	{"page=99999999999999999999", requestOptions, Args{}, ErrParamRange},
+	{"page=1&page=2", requestOptions, Args{}, ErrParamDuplicate},
+	{"size=101", requestOptions, Args{}, ErrParamRange},
+	{"size=0", requestOptions, Args{}, ErrParamRange},
+	{"size=-5", requestOptions, Args{}, ErrParamNegative},
+	{"size=5", &RequestOptions{MinSize: 10}, Args{}, ErrParamRange},
+	{"size=20", &RequestOptions{Sizes: []int{10, 50, 100}}, Args{}, ErrParamNotAllowed},
+	{"size=2.5", requestOptions, Args{}, ErrParamSyntax},
+	{"page=%zz", requestOptions, Args{}, ErrParamForm},
+	{"page=2&page=%zz", requestOptions, Args{}, ErrParamForm},
+	{"q=%zz&page=2", requestOptions, Args{Max: 5, Pos: 3, Page: 2, Size: 10}, nil},
+	{"size=%zz", requestOptions, Args{}, ErrParamForm},
+	{"page=2&size=%zz", requestOptions, Args{}, ErrParamForm},
+	{"lang=%zz", &RequestOptions{Languages: Catalogs}, Args{}, ErrParamForm},
+}
+
+func TestFromRequest(t *testing.T) {
+	for _, ft := range fromRequestTests {
+		r := httptest.NewRequest(http.MethodGet, "/?"+ft.query, nil)
+		got, err := FromRequest(r, ft.o)
+		if !errors.Is(err, ft.err) {
+			t.Error("FromRequest() for: ", ft.query, " Expected: ", ft.err, " Got: ", err)
+			continue
+		}
+		if err == nil && !reflect.DeepEqual(got, ft.want) {
+			t.Error("FromRequest() for: ", ft.query, " Expected: ", ft.want, " Got: ", got)
+		}
+	}
+}
+
+func TestFromRequestForm(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("page=4&size=20"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	a, err := FromRequest(r, nil)
+	if err != nil || a.Page != 4 || a.Size != 20 {
+		t.Error("FromRequest() for form Expected: 4 20 Got: ", a.Page, a.Size, err)
+	}
+
+	// The same parameter in the query string and the form is a duplicate.
+	r = httptest.NewRequest(http.MethodPost, "/?page=3", strings.NewReader("page=4"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	_, err = FromRequest(r, nil)
+	var perr *ParamError
+	if !errors.As(err, &perr) || perr.Param != "page" || perr.Err != ErrParamDuplicate {
+		t.Error("FromRequest() Expected: *ParamError for page, Got: ", err)
+	}
+}
+
+func TestCursorFromRequest(t *testing.T) {
+	codec := &Codec{Key: []byte("secret")}
+	o := &RequestOptions{Codec: codec, Args: Args{Size: 10}}
+	token := &Token{Keys: []string{"42"}, Size: 10}
+
+	r := httptest.NewRequest(http.MethodGet, "/?size=20&cursor="+codec.Encode(token), nil)
+	a, err := CursorFromRequest(r, o)
+	if err != nil {
+		t.Fatal("CursorFromRequest() Error: ", err)
+	}
+	if !reflect.DeepEqual(a.Token, token) || a.Size != 20 {
+		t.Error("CursorFromRequest() Expected: ", token, 20, " Got: ", a.Token, a.Size)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	if a, err = CursorFromRequest(r, o); err != nil || a.Token != nil || a.Size != 10 {
+		t.Error("CursorFromRequest() for first page Got: ", a.Token, a.Size, err)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/?cursor="+token.String(), nil)
+	if _, err = CursorFromRequest(r, o); !errors.Is(err, ErrTokenSignature) {
+		t.Error("CursorFromRequest() Expected: ", ErrTokenSignature, " Got: ", err)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/?size=%zz", nil)
+	if _, err = CursorFromRequest(r, o); !errors.Is(err, ErrParamForm) {
+		t.Error("CursorFromRequest() Expected: ", ErrParamForm, " Got: ", err)
+	}
+}
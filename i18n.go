@@ -0,0 +1,230 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Translator localizes the texts of a pagination. It can be set through Args.Translator.
+// The built-in catalogs are English, Dutch, German and Japanese, any other type implementing this interface can be used as well.
+type Translator interface {
+	// Labels returns the texts of the navigation links. Empty fields are taken from DefaultLabels.
+	Labels() Labels
+	// Summary returns a sentence like "Page 2 of 25, showing 20 records out of 499 total".
+	Summary(p *Pagination) string
+	// FormatNumber formats n with digit grouping, like "1,234,567".
+	FormatNumber(n int) string
+}
+
+// Catalog is a Translator with fixed texts for one language.
+//
+// The summary formats get the page, the amount of pages, the records and the total as formatted numbers,
+// use explicit argument indexes like %[2]s to reorder them.
+// The format is selected by the amount of records on the page.
+type Catalog struct {
+	Text      Labels // Texts of the navigation links
+	None      string // Summary for an empty result set
+	One       string // Summary format for a page with one record
+	Other     string // Summary format for a page with any other amount of records
+	Separator string // Digit group separator, no grouping if empty
+}
+
+// Built-in catalogs.
+var (
+	English = &Catalog{
+		Text:      DefaultLabels,
+		None:      "No records",
+		One:       "Page %[1]s of %[2]s, showing %[3]s record out of %[4]s total",
+		Other:     "Page %[1]s of %[2]s, showing %[3]s records out of %[4]s total",
+		Separator: ",",
+	}
+	Dutch = &Catalog{
+		Text: Labels{
+			First:   "Eerste",
+			Prev:    "Vorige",
+			Next:    "Volgende",
+			Last:    "Laatste",
+			Nav:     "Paginering",
+			Page:    "Pagina %d",
+			Current: "Pagina %d, huidige pagina",
+		},
+		None:      "Geen resultaten",
+		One:       "Pagina %[1]s van %[2]s, %[3]s resultaat van %[4]s in totaal",
+		Other:     "Pagina %[1]s van %[2]s, %[3]s resultaten van %[4]s in totaal",
+		Separator: ".",
+	}
+	German = &Catalog{
+		Text: Labels{
+			First:   "Erste",
+			Prev:    "Zurück",
+			Next:    "Weiter",
+			Last:    "Letzte",
+			Nav:     "Seitennavigation",
+			Page:    "Seite %d",
+			Current: "Seite %d, aktuelle Seite",
+		},
+		None:      "Keine Einträge",
+		One:       "Seite %[1]s von %[2]s, %[3]s Eintrag von insgesamt %[4]s",
+		Other:     "Seite %[1]s von %[2]s, %[3]s Einträge von insgesamt %[4]s",
+		Separator: ".",
+	}
+	Japanese = &Catalog{
+		Text: Labels{
+			First:   "最初",
+			Prev:    "前へ",
+			Next:    "次へ",
+			Last:    "最後",
+			Nav:     "ページナビゲーション",
+			Page:    "%dページ",
+			Current: "%dページ（現在のページ）",
+		},
+		None:      "該当する項目はありません",
+		One:       "%[2]sページ中%[1]sページ目、全%[4]s件中%[3]s件を表示",
+		Other:     "%[2]sページ中%[1]sページ目、全%[4]s件中%[3]s件を表示",
+		Separator: ",",
+	}
+)
+
+// Catalogs are the built-in catalogs by language tag, for use in RequestOptions.Languages.
+var Catalogs = map[string]Translator{
+	"en": English,
+	"nl": Dutch,
+	"de": German,
+	"ja": Japanese,
+}
+
+// Labels implements Translator.
+func (c *Catalog) Labels() Labels {
+	return c.Text
+}
+
+// Summary implements Translator.
+func (c *Catalog) Summary(p *Pagination) string {
+	format := c.Other
+	switch {
+	case p.Empty():
+		return c.None
+	case p.Records() == 1:
+		format = c.One
+	}
+	return fmt.Sprintf(format, c.FormatNumber(p.Page()), c.FormatNumber(p.Pages()), c.FormatNumber(p.Records()), c.FormatNumber(p.Total()))
+}
+
+// FormatNumber implements Translator.
+func (c *Catalog) FormatNumber(n int) string {
+	s := strconv.Itoa(n)
+	if c.Separator == "" {
+		return s
+	}
+	var sign string
+	if n < 0 {
+		sign, s = "-", s[1:]
+	}
+	var b strings.Builder
+	b.WriteString(sign)
+	for i := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteString(c.Separator)
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// translator returns Args.Translator, or English if not set.
+func (p *Pagination) translator() Translator {
+	if p.args.Translator == nil {
+		return English
+	}
+	return p.args.Translator
+}
+
+// Summary returns a localized sentence like "Page 2 of 25, showing 20 records out of 499 total", see Args.Translator.
+func (p *Pagination) Summary() string {
+	return p.translator().Summary(p)
+}
+
+// FormatNumber formats n with the digit grouping of Args.Translator, like "1,234,567".
+func (p *Pagination) FormatNumber(n int) string {
+	return p.translator().FormatNumber(n)
+}
+
+// language selects the translator by the language parameter or, if it is absent or unknown, the Accept-Language header.
+// It returns nil if RequestOptions.Languages is nil or none of the languages matches.
+func (o *RequestOptions) language(r *http.Request) (Translator, error) {
+	if o.Languages == nil {
+		return nil, nil
+	}
+	if name := o.param(o.LangParam, "lang"); name != "-" {
+		s, err := param(r, name)
+		if err != nil {
+			return nil, err
+		}
+		if t := matchLanguage(o.Languages, s); t != nil {
+			return t, nil
+		}
+	}
+	for _, tag := range acceptLanguage(r.Header.Get("Accept-Language")) {
+		if t := matchLanguage(o.Languages, tag); t != nil {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+// matchLanguage returns the translator for the language tag, or for its primary language, like "de" for "de-CH".
+func matchLanguage(languages map[string]Translator, tag string) Translator {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if t, ok := languages[tag]; ok {
+		return t
+	}
+	if primary, _, ok := strings.Cut(tag, "-"); ok {
+		return languages[primary]
+	}
+	return nil
+}
+
+// acceptLanguage returns the language tags of an Accept-Language header, ordered by their quality value.
+// Tags with a quality of 0 are omitted.
+func acceptLanguage(h string) []string {
+	type lang struct {
+		tag string
+		q   float64
+	}
+	var langs []lang
+	for _, s := range strings.Split(h, ",") {
+		tag, params, _ := strings.Cut(s, ";")
+		l := lang{tag: strings.TrimSpace(tag), q: 1}
+		if v, ok := cutPrefix(strings.TrimSpace(params), "q="); ok {
+			q, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			l.q = q
+		}
+		if l.tag != "" && l.tag != "*" && l.q > 0 {
+			langs = append(langs, l)
+		}
+	}
+	sort.SliceStable(langs, func(i, j int) bool { return langs[i].q > langs[j].q })
+	tags := make([]string, len(langs))
+	for i, l := range langs {
+		tags[i] = l.tag
+	}
+	return tags
+}
+
+// cutPrefix is strings.CutPrefix, which is not available in older Go versions.
+func cutPrefix(s, prefix string) (string, bool) {
+	if !strings.HasPrefix(s, prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
@@ -0,0 +1,151 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var summaryTests = []struct {
+	t    Translator
+	a    Args
+	want string
+}{
+	{nil, Args{Page: 2, Records: 20, Total: 499, Size: 20}, "Page 2 of 25, showing 20 records out of 499 total"},
+	{English, Args{Page: 3, Records: 1, Total: 41, Size: 20}, "Page 3 of 3, showing 1 record out of 41 total"},
+	{English, Args{Page: 1, Total: 0, Size: 20}, "No records"},
+	{English, Args{Page: 1200, Records: 10, Total: 1234567, Size: 10}, "Page 1,200 of 123,457, showing 10 records out of 1,234,567 total"},
+	{Dutch, Args{Page: 1200, Records: 10, Total: 1234567, Size: 10}, "Pagina 1.200 van 123.457, 10 resultaten van 1.234.567 in totaal"},
+	{Dutch, Args{Page: 3, Records: 1, Total: 41, Size: 20}, "Pagina 3 van 3, 1 resultaat van 41 in totaal"},
+	{German, Args{Page: 2, Records: 20, Total: 4990, Size: 20}, "Seite 2 von 250, 20 Einträge von insgesamt 4.990"},
+	{German, Args{Page: 3, Records: 1, Total: 41, Size: 20}, "Seite 3 von 3, 1 Eintrag von insgesamt 41"},
+	{Japanese, Args{Page: 2, Records: 20, Total: 4990, Size: 20}, "250ページ中2ページ目、全4,990件中20件を表示"},
+	{Japanese, Args{Page: 1, Total: 0, Size: 20}, "該当する項目はありません"},
+}
+
+func TestSummary(t *testing.T) {
+	for _, st := range summaryTests {
+		st.a.Translator = st.t
+		p, err := New(st.a)
+		if err != nil {
+			t.Error("New() for: ", st.a, " Error: ", err.Error())
+			continue
+		}
+		if got := p.Summary(); got != st.want {
+			t.Error("Summary() for: ", st.a, " Expected: ", st.want, " Got: ", got)
+		}
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		c    *Catalog
+		n    int
+		want string
+	}{
+		{English, 0, "0"},
+		{English, 999, "999"},
+		{English, 1000, "1,000"},
+		{English, -1234567, "-1,234,567"},
+		{Dutch, 123456, "123.456"},
+		{&Catalog{}, 123456, "123456"},
+		{&Catalog{Separator: " "}, 1234, "1 234"},
+	}
+	for _, ft := range tests {
+		if got := ft.c.FormatNumber(ft.n); got != ft.want {
+			t.Error("FormatNumber() for: ", ft.n, " Expected: ", ft.want, " Got: ", got)
+		}
+	}
+}
+
+func TestTranslatorLabels(t *testing.T) {
+	p, _ := New(Args{Max: 3, Pos: 1, Page: 1, Total: 30, Size: 10, Translator: Dutch, Labels: Labels{Next: "Verder"}})
+	l := p.Labels()
+	if l.Prev != "Vorige" || l.Next != "Verder" || l.Gap != "…" {
+		t.Error("Labels() Expected: Vorige, Verder, … Got: ", l)
+	}
+	if got := p.Entries()[1].Label(); got != "Pagina 2" {
+		t.Error("Entry.Label() Expected: Pagina 2 Got: ", got)
+	}
+
+	var b strings.Builder
+	tmpl := template.Must(Templates(template.New("test")))
+	if err := tmpl.ExecuteTemplate(&b, "pagination/plain", p); err != nil {
+		t.Fatal("ExecuteTemplate() Error: ", err.Error())
+	}
+	for _, want := range []string{`aria-label="Paginering"`, ">Verder<", ">Laatste<"} {
+		if !strings.Contains(b.String(), want) {
+			t.Error("ExecuteTemplate() Expected: ", want, " Got: ", b.String())
+		}
+	}
+
+	// Page numbers are grouped like the other numbers of the translator.
+	p, _ = New(Args{Max: 3, Pos: 2, Page: 1234, Total: 20000, Size: 10, Translator: German})
+	if got := p.Entries()[1].Label(); got != "Seite 1.234, aktuelle Seite" {
+		t.Error("Entry.Label() Expected: Seite 1.234, aktuelle Seite Got: ", got)
+	}
+}
+
+func TestAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		h    string
+		want string
+	}{
+		{"", "[]"},
+		{"nl", "[nl]"},
+		{"de-CH, de;q=0.9, en;q=0.8, *;q=0.5", "[de-CH de en]"},
+		{"en;q=0.2, ja", "[ja en]"},
+		{"fr;q=0, nl;q=x, de", "[de]"},
+	}
+	for _, at := range tests {
+		if got := "[" + strings.Join(acceptLanguage(at.h), " ") + "]"; got != at.want {
+			t.Error("acceptLanguage() for: ", at.h, " Expected: ", at.want, " Got: ", got)
+		}
+	}
+}
+
+func TestFromRequestLanguage(t *testing.T) {
+	o := &RequestOptions{Languages: Catalogs}
+	tests := []struct {
+		query, header string
+		o             *RequestOptions
+		want          Translator
+	}{
+		{"", "", o, nil},
+		{"", "nl-BE,nl;q=0.9", o, Dutch},
+		{"", "fr-FR, de;q=0.5", o, German},
+		{"lang=ja", "nl", o, Japanese},
+		{"lang=JA-jp", "", o, Japanese},
+		{"lang=fr", "de", o, German},
+		{"", "fr", &RequestOptions{Languages: Catalogs, Args: Args{Translator: Dutch}}, Dutch},
+		{"lang=de", "", &RequestOptions{Languages: Catalogs, LangParam: "-"}, nil},
+		{"hl=de", "", &RequestOptions{Languages: Catalogs, LangParam: "hl"}, German},
+		{"", "nl", &RequestOptions{}, nil},
+	}
+	for _, lt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/?"+lt.query, nil)
+		if lt.header != "" {
+			r.Header.Set("Accept-Language", lt.header)
+		}
+		a, err := FromRequest(r, lt.o)
+		if err != nil {
+			t.Error("FromRequest() for: ", lt.query, lt.header, " Error: ", err.Error())
+			continue
+		}
+		if a.Translator != lt.want {
+			t.Error("FromRequest() for: ", lt.query, " ", lt.header, " Expected: ", lt.want, " Got: ", a.Translator)
+		}
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/?lang=nl&lang=de", nil)
+	if _, err := FromRequest(r, o); !errors.Is(err, ErrParamDuplicate) {
+		t.Error("FromRequest() for duplicate lang Expected: ", ErrParamDuplicate, " Got: ", err)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+//go:build go1.23
+
+package pagination
+
+import "iter"
+
+// Pages returns an iterator over the pages of a, like NewIterator:
+//
+//	for p, err := range pagination.Pages(a, fetch) {
+//		if err != nil {
+//			...
+//		}
+//		...
+//	}
+//
+// An error is yielded with a nil pagination and ends the iteration.
+func Pages(a Args, fetch FetchFunc) iter.Seq2[*Pagination, error] {
+	return func(yield func(*Pagination, error) bool) {
+		it := NewIterator(a, fetch)
+		for it.Next() {
+			if !yield(it.Pagination(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+//go:build go1.23
+
+package pagination
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPages(t *testing.T) {
+	var got []int
+	for p, err := range Pages(Args{Total: 25, Size: 10}, nil) {
+		if err != nil {
+			t.Fatal("Pages() Error: ", err.Error())
+		}
+		got = append(got, p.Page())
+	}
+	if len(got) != 3 || got[0] != 1 || got[2] != 3 {
+		t.Error("Pages() Expected: [1 2 3] Got: ", got)
+	}
+
+	// Stop early.
+	got = got[:0]
+	for p := range Pages(Args{Total: 25, Size: 10}, nil) {
+		got = append(got, p.Page())
+		break
+	}
+	if len(got) != 1 {
+		t.Error("Pages() with break Expected: [1] Got: ", got)
+	}
+
+	errFetch := errors.New("fetch failed")
+	fetch := func(offset, limit int) (int, int, error) { return 0, 0, errFetch }
+	var errs int
+	for p, err := range Pages(Args{Size: 10}, fetch) {
+		if p != nil || err != errFetch {
+			t.Error("Pages() Expected: ", errFetch, " Got: ", p, err)
+		}
+		errs++
+	}
+	if errs != 1 {
+		t.Error("Pages() Expected: 1 error Got: ", errs)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+// FetchFunc fetches the records of one page for an Iterator.
+// It returns the amount of records fetched and the total amount of records, which may change between pages.
+type FetchFunc func(offset, limit int) (records, total int, err error)
+
+// Iterator walks over all pages of a result set, for batch jobs like exporters:
+//
+//	it := pagination.NewIterator(pagination.Args{Size: 100}, fetch)
+//	for it.Next() {
+//		p := it.Pagination()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator struct {
+	args  Args
+	fetch FetchFunc
+	pag   *Pagination
+	err   error
+	done  bool
+}
+
+// NewIterator returns an Iterator over the pages of a, starting at a.Page, or the first page if it is 0.
+// Without a fetch function, the pages are derived from a.Total and a.Size and Records is computed for every page.
+// Otherwise, fetch is called for every page and determines Records and Total.
+// When the total shrinks below the page being fetched, the iteration ends without an error.
+func NewIterator(a Args, fetch FetchFunc) *Iterator {
+	if a.Page == 0 {
+		a.Page = 1
+	}
+	return &Iterator{args: a, fetch: fetch}
+}
+
+// Next advances to the next page. It returns false when there are no more pages,
+// or when fetching or checking the page failed, see Err.
+func (it *Iterator) Next() bool {
+	if it.done {
+		return false
+	}
+	a := it.args
+	if it.fetch != nil {
+		var err error
+		if a.Records, a.Total, err = it.fetch(a.Offset(), a.Limit()); err != nil {
+			return it.stop(err)
+		}
+		if it.pag != nil && a.Page > a.pages() {
+			// The total shrank below the current page, so the previous page was the last one.
+			it.pag, it.done = nil, true
+			return false
+		}
+	} else {
+		start, end := a.Range()
+		a.Records = end - start
+	}
+	pag, err := New(a)
+	if err != nil {
+		return it.stop(err)
+	}
+	it.pag = pag
+	it.args.Page = pag.Page() + 1
+	it.done = !pag.HasNext()
+	return true
+}
+
+func (it *Iterator) stop(err error) bool {
+	it.pag, it.err, it.done = nil, err, true
+	return false
+}
+
+// Pagination returns the pagination of the current page. Its Offset and Limit methods return the query range.
+// It returns nil before the first call to Next, or after an error.
+func (it *Iterator) Pagination() *Pagination {
+	return it.pag
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,110 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// pagesString renders the pages of an iterator like "1:0-10 2:10-20 3:20-25", with the offsets and the records.
+func pagesString(it *Iterator) string {
+	var s string
+	for it.Next() {
+		p := it.Pagination()
+		if s != "" {
+			s += " "
+		}
+		s += fmt.Sprintf("%d:%d-%d", p.Page(), p.Offset(), p.Offset()+p.Records())
+	}
+	return s
+}
+
+var iteratorTests = []struct {
+	a    Args
+	want string
+}{
+	{Args{Total: 25, Size: 10}, "1:0-10 2:10-20 3:20-25"},
+	{Args{Page: 2, Total: 25, Size: 10}, "2:10-20 3:20-25"},
+	{Args{Total: 20, Size: 10}, "1:0-10 2:10-20"},
+	{Args{Total: 0, Size: 10}, "1:0-0"},
+}
+
+func TestIterator(t *testing.T) {
+	for _, it := range iteratorTests {
+		i := NewIterator(it.a, nil)
+		if got := pagesString(i); got != it.want || i.Err() != nil {
+			t.Error("Iterator for: ", it.a, " Expected: ", it.want, " Got: ", got, i.Err())
+		}
+	}
+
+	i := NewIterator(Args{Page: 4, Total: 25, Size: 10}, nil)
+	if i.Next() || !errors.Is(i.Err(), ErrPageNo) || i.Pagination() != nil {
+		t.Error("Iterator Expected: ", ErrPageNo, " Got: ", i.Err())
+	}
+}
+
+func TestIteratorFetch(t *testing.T) {
+	// The total grows while iterating, which is picked up by the next page.
+	total := 15
+	var calls []string
+	fetch := func(offset, limit int) (int, int, error) {
+		calls = append(calls, fmt.Sprint(offset, "+", limit))
+		if offset == 10 {
+			total = 25
+		}
+		records := total - offset
+		if records > limit {
+			records = limit
+		}
+		return records, total, nil
+	}
+	i := NewIterator(Args{Size: 10}, fetch)
+	if got, want := pagesString(i), "1:0-10 2:10-20 3:20-25"; got != want || i.Err() != nil {
+		t.Error("Iterator Expected: ", want, " Got: ", got, i.Err())
+	}
+	if got, want := fmt.Sprint(calls), "[0+10 10+10 20+10]"; got != want {
+		t.Error("fetch() calls Expected: ", want, " Got: ", got)
+	}
+
+	// The total shrinks while iterating, which ends the iteration early.
+	total, calls = 35, nil
+	fetch = func(offset, limit int) (int, int, error) {
+		calls = append(calls, fmt.Sprint(offset, "+", limit))
+		if offset == 20 {
+			total = 15
+		}
+		records := total - offset
+		if records > limit {
+			records = limit
+		} else if records < 0 {
+			records = 0
+		}
+		return records, total, nil
+	}
+	i = NewIterator(Args{Size: 10}, fetch)
+	if got, want := pagesString(i), "1:0-10 2:10-20"; got != want || i.Err() != nil {
+		t.Error("Iterator Expected: ", want, " Got: ", got, i.Err())
+	}
+	if got, want := fmt.Sprint(calls), "[0+10 10+10 20+10]"; got != want {
+		t.Error("fetch() calls Expected: ", want, " Got: ", got)
+	}
+
+	errFetch := errors.New("fetch failed")
+	fetch = func(offset, limit int) (int, int, error) {
+		if offset > 0 {
+			return 0, 0, errFetch
+		}
+		return limit, 100, nil
+	}
+	i = NewIterator(Args{Size: 10}, fetch)
+	if got, want := pagesString(i), "1:0-10"; got != want || i.Err() != errFetch {
+		t.Error("Iterator Expected: ", want, errFetch, " Got: ", got, i.Err())
+	}
+	if i.Next() {
+		t.Error("Next() after error Expected: false")
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+)
+
+// ErrJSON is returned when unmarshaling a Pagination without a page or size.
+var ErrJSON = errors.New(errBase + "missing page or size")
+
+// Naming is a convention for JSON field names.
+type Naming int
+
+// Supported naming conventions. They only make a difference for field names with multiple words.
+const (
+	SnakeCase Naming = iota // has_next
+	CamelCase               // hasNext
+)
+
+// JSONOptions configure the JSON representation of a Pagination.
+type JSONOptions struct {
+	Naming  Naming // Convention for field names
+	Entries bool   // Include the entries
+	Links   bool   // Include the first, prev, next and last links, see Pagination.Links
+}
+
+// name returns the field name for the naming convention. Field names are passed in snake case.
+// A leading underscore, like in "_links", is kept.
+func (n Naming) name(s string) string {
+	if n != CamelCase {
+		return s
+	}
+	b := []byte(s)
+	for i := 1; i < len(b); i++ {
+		if b[i] == '_' && i+1 < len(b) {
+			b = append(b[:i], b[i+1:]...)
+			b[i] -= 'a' - 'A'
+		}
+	}
+	return string(b)
+}
+
+// jsonObject writes the fields of a JSON object, in order.
+type jsonObject struct {
+	buf    bytes.Buffer
+	naming Naming
+	err    error
+}
+
+func (o *jsonObject) field(name string, v interface{}) {
+	if o.err != nil {
+		return
+	}
+	if o.buf.Len() == 0 {
+		o.buf.WriteByte('{')
+	} else {
+		o.buf.WriteByte(',')
+	}
+	var b []byte
+	if b, o.err = json.Marshal(o.naming.name(name)); o.err != nil {
+		return
+	}
+	o.buf.Write(b)
+	o.buf.WriteByte(':')
+	if b, o.err = json.Marshal(v); o.err != nil {
+		return
+	}
+	o.buf.Write(b)
+}
+
+func (o *jsonObject) bytes() ([]byte, error) {
+	if o.buf.Len() == 0 {
+		o.buf.WriteByte('{')
+	}
+	o.buf.WriteByte('}')
+	return o.buf.Bytes(), o.err
+}
+
+// MarshalJSON implements json.Marshaler. The shape of the object is stable, with the fields in this order:
+//
+//	{
+//		"page": 2,          // Current page
+//		"size": 10,         // Records per page
+//		"total": 499,       // Total amount of records
+//		"pages": 50,        // Total amount of pages
+//		"records": 10,      // Current amount of records
+//		"prev": 1,          // Previous page, 0 if there is none
+//		"next": 3,          // Next page, 0 if there is none
+//		"has_prev": true,
+//		"has_next": true,
+//		"entries": [{"number": 1, "active": false, "ellipsis": false, "url": "?page=1"}, ...],
+//		"links": {"first": "?page=1", "prev": "?page=1", "next": "?page=3", "last": "?page=50"}
+//	}
+//
+// "entries" and "links" are only included when enabled in Args.JSON, which also sets the naming convention.
+func (p *Pagination) MarshalJSON() ([]byte, error) {
+	opt := p.args.JSON
+	o := &jsonObject{naming: opt.Naming}
+	o.field("page", p.Page())
+	o.field("size", p.Size())
+	o.field("total", p.Total())
+	o.field("pages", p.Pages())
+	o.field("records", p.Records())
+	o.field("prev", p.Prev())
+	o.field("next", p.Next())
+	o.field("has_prev", p.HasPrev())
+	o.field("has_next", p.HasNext())
+	if opt.Entries {
+		entries := p.Entries()
+		e := make([]json.RawMessage, len(entries))
+		for i, entry := range entries {
+			eo := &jsonObject{naming: opt.Naming}
+			eo.field("number", entry.Number)
+			eo.field("active", entry.Active)
+			eo.field("ellipsis", entry.Ellipsis)
+			eo.field("url", entry.URL)
+			var err error
+			if e[i], err = eo.bytes(); err != nil && o.err == nil {
+				o.err = err
+			}
+		}
+		o.field("entries", e)
+	}
+	if opt.Links {
+		lo := &jsonObject{naming: opt.Naming}
+		for _, l := range p.Links() {
+			lo.field(l.Rel, l.URL)
+		}
+		b, err := lo.bytes()
+		if err != nil && o.err == nil {
+			o.err = err
+		}
+		o.field("links", json.RawMessage(b))
+	}
+	return o.bytes()
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts the shape of MarshalJSON in either naming convention.
+// Only page, size, total and records are used, all other fields are derived from them.
+// The values are checked like New does, a *ValidationError is returned for bogus values.
+//
+// If the receiver is an existing Pagination, its other Args like Max, Layout and URL are kept.
+func (p *Pagination) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Page    *int `json:"page"`
+		Size    *int `json:"size"`
+		Total   int  `json:"total"`
+		Records int  `json:"records"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	if v.Page == nil || v.Size == nil {
+		return ErrJSON
+	}
+	return p.set(*v.Page, *v.Size, v.Total, v.Records)
+}
+
+// set replaces p by a new Pagination with the given values, keeping the other Args.
+func (p *Pagination) set(page, size, total, records int) error {
+	var a Args
+	if p.args != nil {
+		a = *p.args
+	}
+	a.Page, a.Size, a.Total, a.Records = page, size, total, records
+	pag, err := New(a)
+	if err != nil {
+		return err
+	}
+	*p = *pag
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler. The text form is a query string like "page=2&records=10&size=10&total=30".
+func (p *Pagination) MarshalText() ([]byte, error) {
+	v := url.Values{}
+	v.Set("page", strconv.Itoa(p.Page()))
+	v.Set("size", strconv.Itoa(p.Size()))
+	v.Set("total", strconv.Itoa(p.Total()))
+	v.Set("records", strconv.Itoa(p.Records()))
+	return []byte(v.Encode()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, for the form of MarshalText.
+// Like UnmarshalJSON, the values are checked and other Args of an existing Pagination are kept.
+func (p *Pagination) UnmarshalText(b []byte) error {
+	v, err := url.ParseQuery(string(b))
+	if err != nil {
+		return err
+	}
+	if v.Get("page") == "" || v.Get("size") == "" {
+		return ErrJSON
+	}
+	var n [4]int
+	for i, k := range []string{"page", "size", "total", "records"} {
+		if s := v.Get(k); s != "" {
+			if n[i], err = strconv.Atoi(s); err != nil {
+				return err
+			}
+		}
+	}
+	return p.set(n[0], n[1], n[2], n[3])
+}
@@ -0,0 +1,134 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+var jsonTests = []struct {
+	a    Args
+	want string
+}{
+	{
+		Args{Page: 2, Records: 10, Total: 30, Size: 10},
+		`{"page":2,"size":10,"total":30,"pages":3,"records":10,"prev":1,"next":3,"has_prev":true,"has_next":true}`,
+	},
+	{
+		Args{Page: 1, Total: 0, Size: 10, JSON: JSONOptions{Naming: CamelCase}},
+		`{"page":1,"size":10,"total":0,"pages":0,"records":0,"prev":0,"next":0,"hasPrev":false,"hasNext":false}`,
+	},
+	{
+		Args{Max: 3, Pos: 2, Page: 3, Records: 5, Total: 25, Size: 10, JSON: JSONOptions{Entries: true, Links: true}},
+		`{"page":3,"size":10,"total":25,"pages":3,"records":5,"prev":2,"next":0,"has_prev":true,"has_next":false,` +
+			`"entries":[{"number":1,"active":false,"ellipsis":false,"url":"?page=1"},{"number":2,"active":false,"ellipsis":false,"url":"?page=2"},{"number":3,"active":true,"ellipsis":false,"url":"?page=3"}],` +
+			`"links":{"first":"?page=1","prev":"?page=2","last":"?page=3"}}`,
+	},
+	{
+		Args{Page: 1, Total: 0, Size: 10, JSON: JSONOptions{Entries: true, Links: true}},
+		`{"page":1,"size":10,"total":0,"pages":0,"records":0,"prev":0,"next":0,"has_prev":false,"has_next":false,"entries":[],"links":{}}`,
+	},
+}
+
+func TestMarshalJSON(t *testing.T) {
+	for _, jt := range jsonTests {
+		p, err := New(jt.a)
+		if err != nil {
+			t.Error("New() for: ", jt.a, " Error: ", err.Error())
+			continue
+		}
+		b, err := json.Marshal(p)
+		if err != nil {
+			t.Error("Marshal() for: ", jt.a, " Error: ", err.Error())
+			continue
+		}
+		if got := string(b); got != jt.want {
+			t.Error("Marshal() for: ", jt.a, " Expected: ", jt.want, " Got: ", got)
+		}
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	for _, jt := range jsonTests {
+		p, err := New(jt.a)
+		if err != nil {
+			t.Error("New() for: ", jt.a, " Error: ", err.Error())
+			continue
+		}
+		b, _ := json.Marshal(p)
+
+		// Keep the options, which are not part of the JSON.
+		q := &Pagination{args: &Args{Max: jt.a.Max, Pos: jt.a.Pos, JSON: jt.a.JSON}}
+		if err = json.Unmarshal(b, q); err != nil {
+			t.Error("Unmarshal() for: ", string(b), " Error: ", err.Error())
+			continue
+		}
+		got, _ := json.Marshal(q)
+		if string(got) != string(b) {
+			t.Error("Round trip for: ", jt.a, " Expected: ", string(b), " Got: ", string(got))
+		}
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	var p Pagination
+	if err := json.Unmarshal([]byte(`{"page":2,"size":10,"total":30,"records":10,"hasNext":true}`), &p); err != nil {
+		t.Fatal("Unmarshal() Error: ", err.Error())
+	}
+	if p.Page() != 2 || p.Pages() != 3 || !p.HasNext() {
+		t.Error("Unmarshal() Expected: page 2 of 3 Got: page ", p.Page(), " of ", p.Pages())
+	}
+
+	errs := []struct {
+		s    string
+		want error
+	}{
+		{`{"size":10,"total":30}`, ErrJSON},
+		{`{"page":1,"total":30}`, ErrJSON},
+		{`{"page":4,"size":10,"total":30}`, ErrPageNo},
+		{`{"page":1,"size":10,"total":30,"records":11}`, ErrRecordsSize},
+	}
+	for _, et := range errs {
+		if err := json.Unmarshal([]byte(et.s), new(Pagination)); !errors.Is(err, et.want) {
+			t.Error("Unmarshal() for: ", et.s, " Expected: ", et.want, " Got: ", err)
+		}
+	}
+}
+
+func TestText(t *testing.T) {
+	p, err := New(Args{Page: 2, Records: 10, Total: 30, Size: 10})
+	if err != nil {
+		t.Fatal("New() Error: ", err.Error())
+	}
+	b, err := p.MarshalText()
+	if want := "page=2&records=10&size=10&total=30"; err != nil || string(b) != want {
+		t.Error("MarshalText() Expected: ", want, " Got: ", string(b), err)
+	}
+
+	var q Pagination
+	if err = q.UnmarshalText(b); err != nil {
+		t.Fatal("UnmarshalText() Error: ", err.Error())
+	}
+	if q.Page() != 2 || q.Records() != 10 || q.Size() != 10 || q.Total() != 30 {
+		t.Error("UnmarshalText() Expected: ", p.args, " Got: ", q.args)
+	}
+
+	errs := []struct {
+		s    string
+		want error
+	}{
+		{"size=10", ErrJSON},
+		{"page=x&size=10", nil},
+		{"page=3&size=10&total=10", ErrPageNo},
+	}
+	for _, et := range errs {
+		err := new(Pagination).UnmarshalText([]byte(et.s))
+		if err == nil || et.want != nil && !errors.Is(err, et.want) {
+			t.Error("UnmarshalText() for: ", et.s, " Expected: ", et.want, " Got: ", err)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"strings"
+)
+
+// SortColumn is a column in the sort order of a keyset query.
+type SortColumn struct {
+	Name string // Column name or expression, inserted verbatim in the query
+	Desc bool   // Descending order
+}
+
+// Keyset builds the SQL clauses for cursor pagination over an ordered list of columns.
+// The columns must form a unique sort order, typically by ending with the primary key.
+type Keyset struct {
+	Dialect Dialect
+	Columns []SortColumn
+}
+
+// KeysetClause holds the SQL fragments generated by Keyset.Build.
+type KeysetClause struct {
+	Where   string        // Predicate without "WHERE", empty for the first page
+	OrderBy string        // Sort order without "ORDER BY", reversed when paging backwards
+	Limit   string        // Limit clause for Size+1 rows, to detect if there are more
+	Args    []interface{} // A copy of the args passed to Build, followed by the ones for the fragments
+}
+
+// Build generates the clauses for the page pointed to by t, or the first page if t is nil.
+// Placeholders are numbered after the existing args. The fragments can be combined into a query like:
+//
+//	query := "SELECT * FROM articles WHERE author = $1"
+//	c, err := ks.Build(token, 10, []interface{}{"tim"})
+//	if c.Where != "" {
+//		query += " AND " + c.Where
+//	}
+//	query += " ORDER BY " + c.OrderBy + " " + c.Limit
+//
+// When paging backwards (t.Backward), the rows are returned in reversed order and must be reversed again for display.
+//
+// ErrColumns is returned if there are no columns and ErrToken if the amount of keys doesn't match the columns.
+func (k *Keyset) Build(t *Token, size int, args []interface{}) (*KeysetClause, error) {
+	if len(k.Columns) == 0 {
+		return nil, ErrColumns
+	}
+	if t != nil && len(t.Keys) != len(k.Columns) {
+		return nil, ErrToken
+	}
+	backward := t != nil && t.Backward
+
+	c := &KeysetClause{Args: append([]interface{}(nil), args...)} // Don't write into the backing array of args
+	order := make([]string, len(k.Columns))
+	for i, col := range k.Columns {
+		if col.Desc != backward {
+			order[i] = col.Name + " DESC"
+		} else {
+			order[i] = col.Name + " ASC"
+		}
+	}
+	c.OrderBy = strings.Join(order, ", ")
+
+	if t != nil {
+		c.Where = k.where(c, t.Keys, backward)
+	}
+
+	n := len(c.Args) + 1
+	if k.Dialect == SQLServer {
+		c.Limit = "OFFSET 0 ROWS FETCH NEXT " + k.Dialect.Placeholder(n) + " ROWS ONLY"
+	} else {
+		c.Limit = "LIMIT " + k.Dialect.Placeholder(n)
+	}
+	c.Args = append(c.Args, size+1)
+	return c, nil
+}
+
+// where generates the predicate selecting the rows after keys, in the sort order.
+// If all columns have the same direction, a row value comparison is used: (a, b) > ($1, $2).
+// Otherwise, or if the dialect doesn't support row values, it is expanded into: (a > $1 OR (a = $1 AND b < $2)).
+func (k *Keyset) where(c *KeysetClause, keys []string, backward bool) string {
+	ops := make([]string, len(k.Columns))
+	uniform := k.Dialect != SQLServer
+	for i, col := range k.Columns {
+		if col.Desc != backward {
+			ops[i] = " < "
+		} else {
+			ops[i] = " > "
+		}
+		uniform = uniform && ops[i] == ops[0]
+	}
+
+	if uniform {
+		names := make([]string, len(k.Columns))
+		ph := make([]string, len(k.Columns))
+		for i, col := range k.Columns {
+			names[i] = col.Name
+			ph[i] = k.arg(c, keys[i])
+		}
+		if len(k.Columns) == 1 {
+			return names[0] + ops[0] + ph[0]
+		}
+		return "(" + strings.Join(names, ", ") + ")" + ops[0] + "(" + strings.Join(ph, ", ") + ")"
+	}
+
+	// Numbered placeholders can be reused, "?" needs the arg repeated.
+	numbered := k.Dialect == Postgres || k.Dialect == SQLServer
+	ph := make([]string, len(k.Columns))
+	terms := make([]string, len(k.Columns))
+	for i, col := range k.Columns {
+		var term []string
+		for j := 0; j < i; j++ {
+			if !numbered {
+				ph[j] = k.arg(c, keys[j])
+			}
+			term = append(term, k.Columns[j].Name+" = "+ph[j])
+		}
+		ph[i] = k.arg(c, keys[i])
+		term = append(term, col.Name+ops[i]+ph[i])
+		if len(term) == 1 {
+			terms[i] = term[0]
+		} else {
+			terms[i] = "(" + strings.Join(term, " AND ") + ")"
+		}
+	}
+	return "(" + strings.Join(terms, " OR ") + ")"
+}
+
+// arg appends v to the args and returns its placeholder.
+func (k *Keyset) arg(c *KeysetClause, v string) string {
+	c.Args = append(c.Args, v)
+	return k.Dialect.Placeholder(len(c.Args))
+}
@@ -0,0 +1,99 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"reflect"
+	"testing"
+)
+
+var (
+	ascCols   = []SortColumn{{"created", false}, {"id", false}}
+	mixedCols = []SortColumn{{"score", true}, {"created", false}, {"id", false}}
+)
+
+var keysetTests = []struct {
+	k    Keyset
+	t    *Token
+	want KeysetClause
+}{
+	{
+		Keyset{Postgres, ascCols}, nil,
+		KeysetClause{"", "created ASC, id ASC", "LIMIT $2", []interface{}{"tim", 11}},
+	},
+	{
+		Keyset{Postgres, ascCols}, &Token{Keys: []string{"2018", "42"}},
+		KeysetClause{"(created, id) > ($2, $3)", "created ASC, id ASC", "LIMIT $4", []interface{}{"tim", "2018", "42", 11}},
+	},
+	{
+		Keyset{MySQL, ascCols}, &Token{Keys: []string{"2018", "42"}, Backward: true},
+		KeysetClause{"(created, id) < (?, ?)", "created DESC, id DESC", "LIMIT ?", []interface{}{"tim", "2018", "42", 11}},
+	},
+	{
+		Keyset{SQLite, []SortColumn{{"id", true}}}, &Token{Keys: []string{"42"}},
+		KeysetClause{"id < ?", "id DESC", "LIMIT ?", []interface{}{"tim", "42", 11}},
+	},
+	{
+		Keyset{Postgres, mixedCols}, &Token{Keys: []string{"9", "2018", "42"}},
+		KeysetClause{
+			"(score < $2 OR (score = $2 AND created > $3) OR (score = $2 AND created = $3 AND id > $4))",
+			"score DESC, created ASC, id ASC", "LIMIT $5", []interface{}{"tim", "9", "2018", "42", 11},
+		},
+	},
+	{
+		Keyset{MySQL, mixedCols}, &Token{Keys: []string{"9", "2018", "42"}, Backward: true},
+		KeysetClause{
+			"(score > ? OR (score = ? AND created < ?) OR (score = ? AND created = ? AND id < ?))",
+			"score ASC, created DESC, id DESC", "LIMIT ?", []interface{}{"tim", "9", "9", "2018", "9", "2018", "42", 11},
+		},
+	},
+	{
+		Keyset{SQLServer, ascCols}, &Token{Keys: []string{"2018", "42"}},
+		KeysetClause{
+			"(created > @p2 OR (created = @p2 AND id > @p3))",
+			"created ASC, id ASC", "OFFSET 0 ROWS FETCH NEXT @p4 ROWS ONLY", []interface{}{"tim", "2018", "42", 11},
+		},
+	},
+}
+
+func TestKeyset(t *testing.T) {
+	for _, kt := range keysetTests {
+		got, err := kt.k.Build(kt.t, 10, []interface{}{"tim"})
+		if err != nil {
+			t.Error("Build() for: ", kt.k, kt.t, " Error: ", err)
+			continue
+		}
+		if !reflect.DeepEqual(*got, kt.want) {
+			t.Errorf("Build() for: %v %v\nExpected: %#v\nGot:      %#v", kt.k, kt.t, kt.want, *got)
+		}
+	}
+}
+
+func TestKeysetArgs(t *testing.T) {
+	// Building twice with the same base args, which have spare capacity, must not mix up the args.
+	base := make([]interface{}, 1, 10)
+	base[0] = "tim"
+	k := &Keyset{Postgres, ascCols}
+	c1, err := k.Build(&Token{Keys: []string{"2018", "42"}}, 10, base)
+	if err != nil {
+		t.Fatal("Build() Error: ", err)
+	}
+	if _, err = k.Build(&Token{Keys: []string{"2019", "43"}}, 20, base); err != nil {
+		t.Fatal("Build() Error: ", err)
+	}
+	if want := []interface{}{"tim", "2018", "42", 11}; !reflect.DeepEqual(c1.Args, want) {
+		t.Error("Build() Args Expected: ", want, " Got: ", c1.Args)
+	}
+}
+
+func TestErrKeyset(t *testing.T) {
+	if _, err := (&Keyset{}).Build(nil, 10, nil); err != ErrColumns {
+		t.Error("Build() Expected: ", ErrColumns, " Got: ", err)
+	}
+	k := &Keyset{Postgres, ascCols}
+	if _, err := k.Build(&Token{Keys: []string{"42"}}, 10, nil); err != ErrToken {
+		t.Error("Build() Expected: ", ErrToken, " Got: ", err)
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import "math"
+
+// Layout arranges the pagination entries. It can be selected through Args.Layout.
+// Besides the built-in layouts, any type implementing this interface can be used.
+type Layout interface {
+	// AppendEntries appends the entries for the current page out of pages to dst and returns the extended slice.
+	// Page is always in 1..pages, unless pages is 0 for an empty result set.
+	AppendEntries(dst []Entry, page, pages int, o LayoutOptions) []Entry
+}
+
+// LayoutOptions are passed to a Layout. They are copied from the Args of the same name.
+type LayoutOptions struct {
+	Max      int //Maximum amount of pagination entries
+	Pos      int //Position of active page
+	Boundary int //Pages always shown at each end
+	Siblings int //Pages shown on each side of the current page
+	Jumps    int //Jump entries shown on each side of the current page, 0 for the default of the layout
+}
+
+// Built-in layouts.
+var (
+	// Window is the default layout: a contiguous sliding window of Max entries,
+	// with the current page at Pos.
+	Window Layout = window{}
+	// Centered is a contiguous window of Max entries, with the current page in the middle.
+	// Pos is ignored.
+	Centered Layout = centered{}
+	// Ellipsis always shows Boundary pages at each end and Siblings pages around the current one. Max and Pos are ignored.
+	// Hidden pages are replaced by a gap entry, with its Ellipsis field set:
+	//
+	//	1 … 7 8 [9] 10 11 … 100
+	//
+	// The amount of entries is constant (2*Boundary + 2*Siblings + 3), so the pagination doesn't jump around.
+	// A gap never hides a single page, the page itself is shown instead.
+	Ellipsis Layout = ellipsis{}
+	// Decades shows Boundary pages at each end, Siblings pages around the current one
+	// and the Jumps nearest multiples of ten on each side of those, 3 if Jumps is 0. Max and Pos are ignored:
+	//
+	//	1 [2] 3 … 10 20 30 … 100
+	Decades Layout = decades{}
+	// Logarithmic shows Boundary pages at each end, Siblings pages around the current one
+	// and jumps of 10, 100, 1000 etc. pages away from the current one, up to Jumps on each side, all if Jumps is 0.
+	// It is meant for huge result sets. Max and Pos are ignored:
+	//
+	//	1 … 437 527 … 536 [537] 538 … 547 637 1537 … 2000
+	Logarithmic Layout = logarithmic{}
+)
+
+// usesPos reports if Pos is used by the layout l, which is Window if nil.
+// Custom layouts might use it, so only the built-in ones ignoring it are excluded.
+func usesPos(l Layout) bool {
+	switch l.(type) {
+	case centered, ellipsis, decades, logarithmic:
+		return false
+	}
+	return true
+}
+
+type window struct{}
+
+func (window) AppendEntries(dst []Entry, page, pages int, o LayoutOptions) []Entry {
+	max := o.Max
+	if pages < max {
+		max = pages
+	}
+	sn := page - o.Pos //sn is the start page number of the entries range
+	switch {
+	case sn < 0: //Don't show negative page numbers.
+		sn = 0
+	case max-o.Pos > pages-page:
+		sn = pages - max
+	}
+	return appendRange(dst, page, sn+1, sn+max)
+}
+
+type centered struct{}
+
+func (centered) AppendEntries(dst []Entry, page, pages int, o LayoutOptions) []Entry {
+	o.Pos = (o.Max + 1) / 2
+	return window{}.AppendEntries(dst, page, pages, o)
+}
+
+type ellipsis struct{}
+
+func (ellipsis) AppendEntries(dst []Entry, page, pages int, o LayoutOptions) []Entry {
+	b, s := o.Boundary, o.Siblings
+	// Boundary or Siblings of half the pages show all of them. Checking that first keeps the arithmetic from overflowing.
+	if b >= pages/2 || s >= pages/2 || pages-2*b-2*s <= 3 {
+		return appendRange(dst, page, 1, pages)
+	}
+
+	// Start and end of the siblings range. Near the ends it is shifted, to keep the amount of entries constant.
+	ss := page - s
+	if n := pages - b - 2*s - 1; ss > n {
+		ss = n
+	}
+	if ss < b+2 {
+		ss = b + 2
+	}
+	se := pages - b - 1
+	if page < se-s {
+		se = page + s
+	}
+	if n := b + 2*s + 2; se < n {
+		se = n
+	}
+
+	dst = appendRange(dst, page, 1, b)
+	if ss > b+2 {
+		dst = append(dst, Entry{Ellipsis: true})
+	} else {
+		dst = appendRange(dst, page, b+1, b+1)
+	}
+	dst = appendRange(dst, page, ss, se)
+	if se < pages-b-1 {
+		dst = append(dst, Entry{Ellipsis: true})
+	} else {
+		dst = appendRange(dst, page, pages-b, pages-b)
+	}
+	return appendRange(dst, page, pages-b+1, pages)
+}
+
+type decades struct{}
+
+// decadeJumps is the amount of jumps on each side in the Decades layout, if Jumps is 0.
+const decadeJumps = 3
+
+func (decades) AppendEntries(dst []Entry, page, pages int, o LayoutOptions) []Entry {
+	if o.Jumps == 0 {
+		o.Jumps = decadeJumps
+	}
+	o.limit(pages)
+	if o.Jumps > pages/10+1 {
+		o.Jumps = pages/10 + 1 // There are no more multiples of ten, and 10*(Jumps-1) can't overflow
+	}
+	w := entryWriter{dst: dst, page: page, pages: pages}
+	w.addRange(1, o.Boundary)
+
+	// Multiples of ten below the siblings and above the boundary.
+	first, last := sum(o.Boundary/10*10, 10), (page-o.Siblings-1)/10*10
+	if last > first && last-first > 10*(o.Jumps-1) {
+		first = last - 10*(o.Jumps-1)
+	}
+	w.jumps(first, last)
+
+	w.addRange(page-o.Siblings, sum(page, o.Siblings))
+
+	// Multiples of ten above the siblings and below the boundary.
+	first, last = sum(sum(page, o.Siblings)/10*10, 10), pages-o.Boundary
+	if last > first && last-first > 10*(o.Jumps-1) {
+		last = first + 10*(o.Jumps-1)
+	}
+	w.jumps(first, last)
+
+	w.addRange(pages-o.Boundary+1, pages)
+	return w.end()
+}
+
+type logarithmic struct{}
+
+func (logarithmic) AppendEntries(dst []Entry, page, pages int, o LayoutOptions) []Entry {
+	o.limit(pages)
+	w := entryWriter{dst: dst, page: page, pages: pages}
+	w.addRange(1, o.Boundary)
+
+	// Find the largest jump below, then walk back towards the current page.
+	j, k := 1, 0
+	for j <= (page-o.Boundary-1)/10 && (o.Jumps == 0 || k < o.Jumps) {
+		j *= 10
+		k++
+	}
+	for ; j > 1; j /= 10 {
+		if j > o.Siblings {
+			w.jump(page - j)
+		}
+	}
+
+	w.addRange(page-o.Siblings, sum(page, o.Siblings))
+
+	// Jumps above, while page+j is below the boundary. The check before j *= 10 prevents an overflow.
+	for j, k = 10, 0; j <= pages-o.Boundary-page && (o.Jumps == 0 || k < o.Jumps); j *= 10 {
+		if j > o.Siblings {
+			w.jump(page + j)
+		}
+		if k++; j > (pages-o.Boundary-page)/10 {
+			break
+		}
+	}
+
+	w.addRange(pages-o.Boundary+1, pages)
+	return w.end()
+}
+
+// entryWriter appends page entries in ascending order.
+// Page numbers outside 1..pages or not above the previous one are skipped,
+// gaps between page numbers are marked with an Ellipsis entry.
+// Consecutive jumps are not separated by a gap.
+type entryWriter struct {
+	dst    []Entry
+	page   int
+	pages  int
+	last   int
+	jumped bool
+}
+
+func (w *entryWriter) add(n int) {
+	w.write(n, false)
+}
+
+func (w *entryWriter) jump(n int) {
+	w.write(n, true)
+}
+
+func (w *entryWriter) write(n int, jump bool) {
+	if n <= w.last || n < 1 || n > w.pages {
+		return
+	}
+	if n > w.last+1 && !(jump && w.jumped) {
+		w.dst = append(w.dst, Entry{Ellipsis: true})
+	}
+	w.dst = append(w.dst, Entry{
+		Active: n == w.page,
+		Number: n,
+	})
+	w.last = n
+	w.jumped = jump
+}
+
+// addRange adds the pages from first to last, skipping the ones outside 1..pages or not above the previous one
+// up front, so the time it takes doesn't depend on the range.
+func (w *entryWriter) addRange(first, last int) {
+	if last > w.pages {
+		last = w.pages
+	}
+	if last <= w.last {
+		return
+	}
+	if first <= w.last {
+		first = w.last + 1
+	}
+	for n := first; n <= last; n++ {
+		w.add(n)
+		if n == last {
+			break // n++ would overflow for math.MaxInt
+		}
+	}
+}
+
+// jumps jumps to every tenth page from first to last.
+func (w *entryWriter) jumps(first, last int) {
+	for n := first; n <= last; n += 10 {
+		w.jump(n)
+		if n > last-10 {
+			break // n += 10 could overflow
+		}
+	}
+}
+
+// end marks a gap after the last page number, if any, and returns the entries.
+func (w *entryWriter) end() []Entry {
+	if w.last < w.pages {
+		w.dst = append(w.dst, Entry{Ellipsis: true})
+	}
+	return w.dst
+}
+
+// limit caps Boundary and Siblings at pages, as more can't be shown anyway.
+// This keeps the arithmetic of the layouts from overflowing.
+func (o *LayoutOptions) limit(pages int) {
+	if o.Boundary > pages {
+		o.Boundary = pages
+	}
+	if o.Siblings > pages {
+		o.Siblings = pages
+	}
+}
+
+// sum returns a+b for non-negative numbers, or math.MaxInt if it overflows.
+func sum(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
+
+// appendRange appends an entry for every page number from first to last (inclusive) to dst.
+func appendRange(dst []Entry, page, first, last int) []Entry {
+	for n := first; n <= last; n++ {
+		dst = append(dst, Entry{
+			Active: n == page,
+			Number: n,
+		})
+		if n == last {
+			break // n++ would overflow for math.MaxInt
+		}
+	}
+	return dst
+}
@@ -0,0 +1,124 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// entriesString renders entries like "1 … 7 8 [9] 10 11 … 100", for readable test tables.
+func entriesString(entries []Entry) string {
+	s := make([]string, len(entries))
+	for i, e := range entries {
+		switch {
+		case e.Ellipsis:
+			s[i] = "…"
+		case e.Active:
+			s[i] = "[" + strconv.Itoa(e.Number) + "]"
+		default:
+			s[i] = strconv.Itoa(e.Number)
+		}
+	}
+	return strings.Join(s, " ")
+}
+
+var layoutTests = []struct {
+	a    Args
+	want string
+}{
+	{Args{Max: 5, Pos: 2, Page: 5, Total: 100, Size: 10}, "4 [5] 6 7 8"},
+	{Args{Max: 5, Pos: 2, Page: 5, Total: 100, Size: 10, Layout: Window}, "4 [5] 6 7 8"},
+	{Args{Max: 9, Pos: 3, Page: 2, Total: 30, Size: 10, Layout: Window}, "1 [2] 3"},
+	{Args{Max: 5, Pos: 1, Page: 5, Total: 100, Size: 10, Layout: Centered}, "3 4 [5] 6 7"},
+	{Args{Max: 4, Pos: 1, Page: 5, Total: 100, Size: 10, Layout: Centered}, "4 [5] 6 7"},
+	{Args{Max: 5, Pos: 1, Page: 1, Total: 100, Size: 10, Layout: Centered}, "[1] 2 3 4 5"},
+	{Args{Max: 5, Pos: 1, Page: 10, Total: 100, Size: 10, Layout: Centered}, "6 7 8 9 [10]"},
+	{Args{Max: 5, Page: 5, Total: 100, Size: 10, Layout: Centered}, "3 4 [5] 6 7"},
+
+	{Args{Page: 1, Total: 100, Size: 10, Layout: Ellipsis, Boundary: 1, Siblings: 1}, "[1] 2 3 4 5 … 10"},
+	{Args{Page: 4, Total: 100, Size: 10, Layout: Ellipsis, Boundary: 1, Siblings: 1}, "1 2 3 [4] 5 … 10"},
+	{Args{Page: 5, Total: 100, Size: 10, Layout: Ellipsis, Boundary: 1, Siblings: 1}, "1 … 4 [5] 6 … 10"},
+	{Args{Max: 9, Page: 5, Total: 100, Size: 10, Layout: Ellipsis, Boundary: 1, Siblings: 1}, "1 … 4 [5] 6 … 10"},
+	{Args{Page: 7, Total: 100, Size: 10, Layout: Ellipsis, Boundary: 1, Siblings: 1}, "1 … 6 [7] 8 9 10"},
+	{Args{Page: 10, Total: 100, Size: 10, Layout: Ellipsis, Boundary: 1, Siblings: 1}, "1 … 6 7 8 9 [10]"},
+	{Args{Page: 4, Total: 70, Size: 10, Layout: Ellipsis, Boundary: 1, Siblings: 1}, "1 2 3 [4] 5 6 7"},
+	{Args{Page: 9, Total: 1000, Size: 10, Layout: Ellipsis, Boundary: 1, Siblings: 2}, "1 … 7 8 [9] 10 11 … 100"},
+	{Args{Page: 50, Total: 1000, Size: 10, Layout: Ellipsis, Boundary: 0, Siblings: 1}, "… 49 [50] 51 …"},
+	{Args{Page: 10, Total: 200, Size: 10, Layout: Ellipsis, Boundary: 2, Siblings: 0}, "1 2 … [10] … 19 20"},
+	{Args{Page: 1, Total: 0, Size: 10, Layout: Ellipsis, Boundary: 1, Siblings: 1}, ""},
+	{Args{Page: 2, Total: 1000, Size: 10, Layout: Decades, Boundary: 1, Siblings: 1, Jumps: 3}, "1 [2] 3 … 10 20 30 … 100"},
+	{Args{Page: 55, Total: 1000, Size: 10, Layout: Decades, Boundary: 1, Siblings: 1, Jumps: 2}, "1 … 40 50 … 54 [55] 56 … 60 70 … 100"},
+	{Args{Page: 55, Total: 1000, Size: 10, Layout: Decades, Boundary: 0, Siblings: 0, Jumps: 1}, "… 50 … [55] … 60 …"},
+	{Args{Page: 7, Total: 350, Size: 10, Layout: Decades, Boundary: 1, Siblings: 1}, "1 … 6 [7] 8 … 10 20 30 … 35"},
+	{Args{Page: 95, Total: 1000, Size: 10, Layout: Decades, Boundary: 2, Siblings: 1, Jumps: 2}, "1 2 … 80 90 … 94 [95] 96 … 99 100"},
+	{Args{Page: 500000, Total: 10000000, Size: 10, Layout: Decades, Boundary: 1, Siblings: 1}, "1 … 499970 499980 499990 … 499999 [500000] 500001 … 500010 500020 500030 … 1000000"},
+	{Args{Page: 537, Total: 20000, Size: 10, Layout: Logarithmic, Boundary: 1, Siblings: 1}, "1 … 437 527 … 536 [537] 538 … 547 637 1537 … 2000"},
+	{Args{Page: 537, Total: 20000, Size: 10, Layout: Logarithmic, Boundary: 1, Siblings: 1, Jumps: 1}, "1 … 527 … 536 [537] 538 … 547 … 2000"},
+	{Args{Page: 1, Total: 20000, Size: 10, Layout: Logarithmic, Boundary: 1, Siblings: 1}, "[1] 2 … 11 101 1001 … 2000"},
+	{Args{Page: 3, Total: 50, Size: 10, Layout: Logarithmic, Boundary: 1, Siblings: 1}, "1 2 [3] 4 5"},
+	{Args{Page: 1, Total: 0, Size: 10, Layout: Logarithmic, Boundary: 1, Siblings: 1}, ""},
+}
+
+// reversed is a custom Layout, showing all pages from last to first.
+type reversed struct{}
+
+func (reversed) AppendEntries(dst []Entry, page, pages int, o LayoutOptions) []Entry {
+	for n := pages; n > 0; n-- {
+		dst = append(dst, Entry{Active: n == page, Number: n})
+	}
+	return dst
+}
+
+func TestLayout(t *testing.T) {
+	tests := append(layoutTests, struct {
+		a    Args
+		want string
+	}{Args{Page: 2, Total: 30, Size: 10, Layout: reversed{}}, "3 [2] 1"})
+
+	for _, et := range tests {
+		p, err := New(et.a)
+		if err != nil {
+			t.Error("New() for: ", et.a, " Error: ", err.Error())
+			continue
+		}
+		if got := entriesString(p.Entries()); got != et.want {
+			t.Error("Entries() for: ", et.a, " Expected: ", et.want, " Got: ", got)
+		}
+	}
+}
+
+func TestLayoutLimits(t *testing.T) {
+	max := strconv.Itoa(math.MaxInt)
+	before := strconv.Itoa(math.MaxInt - 1)
+	tests := []struct {
+		a    Args
+		want string
+	}{
+		// Huge options take no longer than the pages they show.
+		{Args{Page: 5, Total: 10, Size: 1, Layout: Ellipsis, Boundary: math.MaxInt}, "1 2 3 4 [5] 6 7 8 9 10"},
+		{Args{Page: 5, Total: 10, Size: 1, Layout: Ellipsis, Siblings: math.MaxInt}, "1 2 3 4 [5] 6 7 8 9 10"},
+		{Args{Page: 5, Total: 10, Size: 1, Layout: Decades, Boundary: math.MaxInt, Jumps: math.MaxInt}, "1 2 3 4 [5] 6 7 8 9 10"},
+		{Args{Page: 5, Total: 10, Size: 1, Layout: Decades, Siblings: math.MaxInt}, "1 2 3 4 [5] 6 7 8 9 10"},
+		{Args{Page: 5, Total: 10, Size: 1, Layout: Logarithmic, Boundary: math.MaxInt}, "1 2 3 4 [5] 6 7 8 9 10"},
+		{Args{Page: 5, Total: 10, Size: 1, Layout: Logarithmic, Siblings: math.MaxInt}, "1 2 3 4 [5] 6 7 8 9 10"},
+		// Page numbers near math.MaxInt don't overflow.
+		{Args{Page: math.MaxInt - 1, Total: math.MaxInt, Size: 1, Layout: Ellipsis, Boundary: 1, Siblings: 1}, "1 … " + strconv.Itoa(math.MaxInt-4) + " " + strconv.Itoa(math.MaxInt-3) + " " + strconv.Itoa(math.MaxInt-2) + " [" + before + "] " + max},
+		{Args{Page: math.MaxInt - 1, Total: math.MaxInt, Size: 1, Layout: Decades, Boundary: 1, Siblings: 1, Jumps: 1}, "1 … " + strconv.Itoa((math.MaxInt-3)/10*10) + " … " + strconv.Itoa(math.MaxInt-2) + " [" + before + "] " + max},
+		{Args{Page: math.MaxInt - 1, Total: math.MaxInt, Size: 1, Layout: Logarithmic, Boundary: 1, Siblings: 1, Jumps: 1}, "1 … " + strconv.Itoa(math.MaxInt-11) + " … " + strconv.Itoa(math.MaxInt-2) + " [" + before + "] " + max},
+	}
+	for _, et := range tests {
+		p, err := New(et.a)
+		if err != nil {
+			t.Error("New() for: ", et.a, " Error: ", err.Error())
+			continue
+		}
+		if got := entriesString(p.Entries()); got != et.want {
+			t.Error("Entries() for: ", et.a, " Expected: ", et.want, " Got: ", got)
+		}
+	}
+}
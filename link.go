@@ -0,0 +1,242 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ErrLinkHeader is returned by ParseLinkHeader for a malformed header value.
+var ErrLinkHeader = errors.New(errBase + "malformed Link header")
+
+// Link is a single link in an RFC 8288 Link header.
+type Link struct {
+	URL    string            // Target URI, as written in the header
+	Rel    string            // Relation type(s), space separated
+	Params map[string]string // Other parameters, like "title". Names are lower case.
+}
+
+// Links is a list of links, as returned by ParseLinkHeader.
+type Links []Link
+
+// Rel returns the first link with the relation type rel.
+// Relation types are compared case-insensitively. A link with multiple relation types, like rel="next last", matches any of them.
+func (l Links) Rel(rel string) (Link, bool) {
+	for _, link := range l {
+		for _, r := range strings.Fields(link.Rel) {
+			if strings.EqualFold(r, rel) {
+				return link, true
+			}
+		}
+	}
+	return Link{}, false
+}
+
+// Links returns the first, prev, next and last links of the pagination, omitting those that don't apply.
+// See URL for how the links are built. REST APIs should set an absolute Args.URL.
+func (p *Pagination) Links() Links {
+	var l Links
+	add := func(rel, url string) {
+		if url != "" {
+			l = append(l, Link{URL: url, Rel: rel})
+		}
+	}
+	if p.pages > 0 {
+		add("first", p.FirstURL())
+	}
+	add("prev", p.PrevURL())
+	add("next", p.NextURL())
+	add("last", p.LastURL())
+	return l
+}
+
+// LinkHeader returns the Links as an RFC 8288 Link header value, like:
+//
+//	<https://api.example.com/items?page=1>; rel="first", <https://api.example.com/items?page=3>; rel="next"
+func (p *Pagination) LinkHeader() string {
+	return p.Links().String()
+}
+
+// SetLinkHeader sets the Link header on w. Nothing is set if there are no links.
+func (p *Pagination) SetLinkHeader(w http.ResponseWriter) {
+	if h := p.LinkHeader(); h != "" {
+		w.Header().Set("Link", h)
+	}
+}
+
+// String formats the links as an RFC 8288 Link header value.
+func (l Links) String() string {
+	s := make([]string, len(l))
+	for i, link := range l {
+		var b strings.Builder
+		b.WriteString("<" + link.URL + ">")
+		if link.Rel != "" {
+			b.WriteString(`; rel=` + quote(link.Rel))
+		}
+		names := make([]string, 0, len(link.Params))
+		for k := range link.Params {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		for _, k := range names {
+			b.WriteString("; " + k + "=" + quote(link.Params[k]))
+		}
+		s[i] = b.String()
+	}
+	return strings.Join(s, ", ")
+}
+
+// quote returns s as a quoted-string.
+func quote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// LinksFromHeader parses all Link headers in h. See ParseLinkHeader.
+func LinksFromHeader(h http.Header) (Links, error) {
+	var links Links
+	for _, v := range h[http.CanonicalHeaderKey("Link")] {
+		l, err := ParseLinkHeader(v)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, l...)
+	}
+	return links, nil
+}
+
+// ParseLinkHeader parses an RFC 8288 Link header value, so that clients can follow the links:
+//
+//	links, err := pagination.ParseLinkHeader(resp.Header.Get("Link"))
+//	if next, ok := links.Rel("next"); ok {
+//		// Fetch next.URL
+//	}
+//
+// The URLs are returned as written, relative URLs must be resolved by the caller.
+// ErrLinkHeader is returned for a malformed value.
+func ParseLinkHeader(h string) (Links, error) {
+	var links Links
+	s := linkScanner(h)
+	for {
+		s.space()
+		if s.empty() {
+			return links, nil
+		}
+		if !s.consume('<') {
+			return nil, ErrLinkHeader
+		}
+		i := strings.IndexByte(string(s), '>')
+		if i < 0 {
+			return nil, ErrLinkHeader
+		}
+		link := Link{URL: strings.TrimSpace(string(s[:i]))}
+		s = s[i+1:]
+
+		for s.space(); s.consume(';'); s.space() {
+			s.space()
+			name := strings.ToLower(s.token())
+			if name == "" {
+				return nil, ErrLinkHeader
+			}
+			var value string
+			s.space()
+			if s.consume('=') {
+				s.space()
+				var ok bool
+				if value, ok = s.value(); !ok {
+					return nil, ErrLinkHeader
+				}
+			}
+			// Only the first occurrence of a parameter counts.
+			if name == "rel" {
+				if link.Rel == "" {
+					link.Rel = value
+				}
+				continue
+			}
+			if link.Params == nil {
+				link.Params = make(map[string]string)
+			}
+			if _, ok := link.Params[name]; !ok {
+				link.Params[name] = value
+			}
+		}
+		links = append(links, link)
+
+		if s.empty() {
+			return links, nil
+		}
+		if !s.consume(',') {
+			return nil, ErrLinkHeader
+		}
+	}
+}
+
+// linkScanner is the remaining input of ParseLinkHeader.
+type linkScanner string
+
+func (s *linkScanner) empty() bool {
+	return len(*s) == 0
+}
+
+func (s *linkScanner) space() {
+	*s = linkScanner(strings.TrimLeft(string(*s), " \t"))
+}
+
+func (s *linkScanner) consume(c byte) bool {
+	if len(*s) > 0 && (*s)[0] == c {
+		*s = (*s)[1:]
+		return true
+	}
+	return false
+}
+
+// token consumes an RFC 7230 token.
+func (s *linkScanner) token() string {
+	i := 0
+	for i < len(*s) && isTokenChar((*s)[i]) {
+		i++
+	}
+	t := string((*s)[:i])
+	*s = (*s)[i:]
+	return t
+}
+
+// value consumes a token or a quoted-string.
+func (s *linkScanner) value() (string, bool) {
+	if !s.consume('"') {
+		t := s.token()
+		return t, t != ""
+	}
+	var b strings.Builder
+	for i := 0; i < len(*s); i++ {
+		switch c := (*s)[i]; c {
+		case '"':
+			*s = (*s)[i+1:]
+			return b.String(), true
+		case '\\':
+			i++
+			if i == len(*s) {
+				return "", false
+			}
+			b.WriteByte((*s)[i])
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", false // Unterminated
+}
+
+func isTokenChar(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	default:
+		return strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0
+	}
+}
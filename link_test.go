@@ -0,0 +1,129 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+var linkHeaderTests = []struct {
+	a    Args
+	want string
+}{
+	{
+		Args{Page: 2, Total: 30, Size: 10, URL: mustParseURL("https://api.example.com/items?sort=id")},
+		`<https://api.example.com/items?page=1&sort=id>; rel="first", ` +
+			`<https://api.example.com/items?page=1&sort=id>; rel="prev", ` +
+			`<https://api.example.com/items?page=3&sort=id>; rel="next", ` +
+			`<https://api.example.com/items?page=3&sort=id>; rel="last"`,
+	},
+	{
+		Args{Page: 1, Total: 30, Size: 10},
+		`<?page=1>; rel="first", <?page=2>; rel="next", <?page=3>; rel="last"`,
+	},
+	{
+		Args{Page: 1, Total: 5, Size: 10},
+		`<?page=1>; rel="first", <?page=1>; rel="last"`,
+	},
+	{
+		Args{Page: 1, Total: 0, Size: 10},
+		``,
+	},
+}
+
+func TestLinkHeader(t *testing.T) {
+	for _, lt := range linkHeaderTests {
+		p, err := New(lt.a)
+		if err != nil {
+			t.Error("New() for: ", lt.a, " Error: ", err.Error())
+			continue
+		}
+		if got := p.LinkHeader(); got != lt.want {
+			t.Errorf("LinkHeader() for: %v\nExpected: %s\nGot:      %s", lt.a, lt.want, got)
+		}
+
+		// The parser must understand what we generate.
+		links, err := ParseLinkHeader(p.LinkHeader())
+		if err != nil {
+			t.Error("ParseLinkHeader() for: ", p.LinkHeader(), " Error: ", err)
+		}
+		if !reflect.DeepEqual(links, p.Links()) {
+			t.Error("ParseLinkHeader() Expected: ", p.Links(), " Got: ", links)
+		}
+
+		w := httptest.NewRecorder()
+		p.SetLinkHeader(w)
+		if got, ok := w.Header()["Link"]; ok != (lt.want != "") || ok && got[0] != lt.want {
+			t.Error("SetLinkHeader() for: ", lt.a, " Expected: ", lt.want, " Got: ", got)
+		}
+	}
+}
+
+var parseLinkHeaderTests = []struct {
+	h    string
+	want Links
+}{
+	{``, nil},
+	{`<https://example.com/?page=2>; rel="next"`, Links{{URL: "https://example.com/?page=2", Rel: "next"}}},
+	{
+		`<https://example.com/?page=2>;rel=next,<https://example.com/?page=9>; rel="last"`,
+		Links{{URL: "https://example.com/?page=2", Rel: "next"}, {URL: "https://example.com/?page=9", Rel: "last"}},
+	},
+	{
+		` < /items?page=2 > ; REL = "next last" ; title="Page 2, \"the end\"" ; rel=prev ; hreflang=en , </items?page=1>; rel="first"`,
+		Links{
+			{URL: "/items?page=2", Rel: "next last", Params: map[string]string{"title": `Page 2, "the end"`, "hreflang": "en"}},
+			{URL: "/items?page=1", Rel: "first"},
+		},
+	},
+	{`</items>; crossorigin`, Links{{URL: "/items", Params: map[string]string{"crossorigin": ""}}}},
+}
+
+func TestParseLinkHeader(t *testing.T) {
+	for _, pt := range parseLinkHeaderTests {
+		got, err := ParseLinkHeader(pt.h)
+		if err != nil {
+			t.Error("ParseLinkHeader() for: ", pt.h, " Error: ", err)
+			continue
+		}
+		if !reflect.DeepEqual(got, pt.want) {
+			t.Errorf("ParseLinkHeader() for: %s\nExpected: %#v\nGot:      %#v", pt.h, pt.want, got)
+		}
+	}
+
+	for _, h := range []string{
+		`https://example.com/; rel="next"`,
+		`<https://example.com/; rel="next"`,
+		`<https://example.com/>; rel="next`,
+		`<https://example.com/>; rel=`,
+		`<https://example.com/>; ="next"`,
+		`<https://example.com/> rel="next"`,
+		`<https://example.com/>; rel="next" <https://example.com/>`,
+	} {
+		if _, err := ParseLinkHeader(h); err != ErrLinkHeader {
+			t.Error("ParseLinkHeader() for: ", h, " Expected: ", ErrLinkHeader, " Got: ", err)
+		}
+	}
+}
+
+func TestLinksRel(t *testing.T) {
+	links := Links{{URL: "/2", Rel: "next last"}, {URL: "/1", Rel: "prev"}}
+	if l, ok := links.Rel("LAST"); !ok || l.URL != "/2" {
+		t.Error("Rel(LAST) Expected: /2 Got: ", l, ok)
+	}
+	if l, ok := links.Rel("first"); ok {
+		t.Error("Rel(first) Expected: none Got: ", l)
+	}
+
+	w := httptest.NewRecorder()
+	w.Header().Add("Link", `</2>; rel="next"`)
+	w.Header().Add("Link", `</1>; rel="prev"`)
+	got, err := LinksFromHeader(w.Header())
+	if err != nil || len(got) != 2 || got[1].Rel != "prev" {
+		t.Error("LinksFromHeader() Got: ", got, err)
+	}
+}
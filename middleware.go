@@ -0,0 +1,145 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// ErrNoContext is returned by Finalize for a context without pagination parameters, which means the Middleware was not used.
+var ErrNoContext = errors.New(errBase + "no pagination in context")
+
+type contextKey struct{}
+
+// ErrorHandler writes the response for an error, like TextError and ProblemError.
+type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+
+// Middleware returns a net/http middleware which parses the pagination parameters of every request with FromRequest
+// and stores the Args in the request context, for FromContext and Finalize.
+// If Args.URL is not set, it is set to the request URL, so that links keep the other query parameters.
+//
+// Requests with bad parameters never reach the next handler, they are answered by onError instead.
+// TextError is used if onError is nil.
+func Middleware(o *RequestOptions, onError ErrorHandler) func(http.Handler) http.Handler {
+	if onError == nil {
+		onError = TextError
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			a, err := FromRequest(r, o)
+			if err != nil {
+				onError(w, r, err)
+				return
+			}
+			if a.URL == nil {
+				a.URL = r.URL
+			}
+			next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), a)))
+		})
+	}
+}
+
+// NewContext returns a copy of ctx which carries a. It is used by the Middleware and can be used in tests.
+func NewContext(ctx context.Context, a Args) context.Context {
+	return context.WithValue(ctx, contextKey{}, a)
+}
+
+// FromContext returns the Args stored by the Middleware. Page and Size are set, Total and Records are not yet known.
+// Use Offset and Limit of the Args to query the current page.
+// The boolean is false if ctx doesn't carry Args.
+func FromContext(ctx context.Context) (a Args, ok bool) {
+	a, ok = ctx.Value(contextKey{}).(Args)
+	return
+}
+
+// Finalize creates the pagination for the Args stored by the Middleware, once the handler knows the total and the amount of records.
+// Errors of New are returned as is, so a page number beyond the last page results in a *ValidationError wrapping ErrPageNo.
+// Pass it to an ErrorHandler, which uses StatusCode to answer with 400 Bad Request.
+func Finalize(ctx context.Context, total, records int) (*Pagination, error) {
+	a, ok := FromContext(ctx)
+	if !ok {
+		return nil, ErrNoContext
+	}
+	a.Total, a.Records = total, records
+	return New(a)
+}
+
+// StatusCode returns the HTTP status code for an error of this package:
+// 400 Bad Request for a *ParamError or a page number out of range, 500 Internal Server Error for anything else.
+// A *ValidationError is only a bad request if all its violations are about the page number,
+// so violations caused by the server, like a wrong total, are not disclosed to the client.
+func StatusCode(err error) int {
+	var pe *ParamError
+	if errors.As(err, &pe) {
+		return http.StatusBadRequest
+	}
+	var ve *ValidationError
+	if errors.As(err, &ve) {
+		for _, v := range ve.Violations {
+			if v.Err != ErrPageNo && v.Err != ErrPageMin {
+				return http.StatusInternalServerError
+			}
+		}
+		if len(ve.Violations) > 0 {
+			return http.StatusBadRequest
+		}
+		return http.StatusInternalServerError
+	}
+	if errors.Is(err, ErrPageNo) || errors.Is(err, ErrPageMin) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// TextError is an ErrorHandler which responds with a plain text error message and the status code of StatusCode.
+// The message of a server error is not disclosed.
+func TextError(w http.ResponseWriter, r *http.Request, err error) {
+	code := StatusCode(err)
+	msg := http.StatusText(code)
+	if code < 500 {
+		msg = err.Error()
+	}
+	http.Error(w, msg, code)
+}
+
+// Problem is the RFC 7807 problem details object written by ProblemError.
+type Problem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+// InvalidParam describes a bad request parameter in a Problem.
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// ProblemError is an ErrorHandler which responds with RFC 7807 problem details in JSON,
+// with the status code of StatusCode. A *ParamError is listed in "invalid-params".
+// The details of a server error are not disclosed.
+func ProblemError(w http.ResponseWriter, r *http.Request, err error) {
+	p := Problem{
+		Type:   "about:blank",
+		Status: StatusCode(err),
+	}
+	p.Title = http.StatusText(p.Status)
+	if p.Status < 500 {
+		p.Detail = err.Error()
+	}
+	var pe *ParamError
+	if errors.As(err, &pe) {
+		p.InvalidParams = []InvalidParam{{Name: pe.Param, Reason: pe.Err.Error()}}
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
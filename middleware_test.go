@@ -0,0 +1,132 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// listHandler finalizes the pagination for 23 records and writes it like "3/3 21-23 /?page=2&q=x".
+var listHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	a, ok := FromContext(r.Context())
+	if !ok {
+		TextError(w, r, ErrNoContext)
+		return
+	}
+	start, end := a.Offset(), a.Offset()+a.Limit()
+	if end > 23 {
+		end = 23
+	}
+	if end < start {
+		end = start
+	}
+	p, err := Finalize(r.Context(), 23, end-start)
+	if err != nil {
+		ProblemError(w, r, err)
+		return
+	}
+	fmt.Fprint(w, p.Page(), "/", p.Pages(), " ", start+1, "-", end, " ", p.PrevURL())
+})
+
+var middlewareTests = []struct {
+	query string
+	code  int
+	want  string
+}{
+	{"q=x", http.StatusOK, "1/3 1-10 "},
+	{"q=x&page=3", http.StatusOK, "3/3 21-23 /?page=2&q=x"},
+	{"q=x&page=3&size=5", http.StatusOK, "3/5 11-15 /?page=2&q=x&size=5"},
+	{"page=0", http.StatusBadRequest, "Error in pagination: parameter out of range: page=\"0\"\n"},
+	{"page=x&size=5", http.StatusBadRequest, "Error in pagination: parameter is not a number: page=\"x\"\n"},
+	{"size=500", http.StatusBadRequest, "Error in pagination: parameter out of range: size=\"500\"\n"},
+	{"q=%zz&page=%zz", http.StatusBadRequest, "Error in pagination: malformed parameter: page=\"%zz\"\n"},
+}
+
+func TestMiddleware(t *testing.T) {
+	h := Middleware(&RequestOptions{MaxSize: 100, Args: Args{Size: 10}}, nil)(listHandler)
+	for _, mt := range middlewareTests {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?"+mt.query, nil))
+		if w.Code != mt.code || w.Body.String() != mt.want {
+			t.Error("Middleware for: ", mt.query, " Expected: ", mt.code, " ", mt.want, " Got: ", w.Code, " ", w.Body.String())
+		}
+	}
+
+	// The page number is checked by Finalize.
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?page=4", nil))
+	var p Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil || w.Code != http.StatusBadRequest || p.Status != http.StatusBadRequest || !strings.Contains(p.Detail, "Page = 4") {
+		t.Error("Middleware for: page=4 Expected: ", http.StatusBadRequest, " Got: ", w.Code, " ", w.Body.String())
+	}
+
+	// Without the middleware, there is nothing to finalize.
+	if _, err := Finalize(context.Background(), 23, 10); err != ErrNoContext {
+		t.Error("Finalize() Expected: ", ErrNoContext, " Got: ", err)
+	}
+	w = httptest.NewRecorder()
+	listHandler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusInternalServerError || w.Body.String() != "Internal Server Error\n" {
+		t.Error("Handler without Middleware Expected: ", http.StatusInternalServerError, " Got: ", w.Code, " ", w.Body.String())
+	}
+}
+
+func TestProblemError(t *testing.T) {
+	h := Middleware(nil, ProblemError)(listHandler)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?size=-1", nil))
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Error("Content-Type Expected: application/problem+json Got: ", ct)
+	}
+	want := `{"type":"about:blank","title":"Bad Request","status":400,` +
+		`"detail":"Error in pagination: parameter is negative: size=\"-1\"",` +
+		`"invalid-params":[{"name":"size","reason":"Error in pagination: parameter is negative"}]}` + "\n"
+	if got := w.Body.String(); w.Code != http.StatusBadRequest || got != want {
+		t.Error("ProblemError() Expected: ", want, " Got: ", w.Code, " ", got)
+	}
+
+	w = httptest.NewRecorder()
+	ProblemError(w, nil, errors.New("database down"))
+	want = `{"type":"about:blank","title":"Internal Server Error","status":500}` + "\n"
+	if got := w.Body.String(); w.Code != http.StatusInternalServerError || got != want {
+		t.Error("ProblemError() Expected: ", want, " Got: ", w.Code, " ", got)
+	}
+}
+
+func TestStatusCode(t *testing.T) {
+	_, pageErr := New(Args{Page: 5, Total: 10, Size: 10})
+	_, sizeErr := New(Args{Page: 1, Total: 10})
+	_, mixedErr := New(Args{Page: 5, Total: 10, Size: 10, Records: 20})
+	r := httptest.NewRequest(http.MethodGet, "/?q=%zz&page=%zz", nil)
+	_, formErr := FromRequest(r, nil)
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{&ParamError{"page", "x", ErrParamSyntax}, http.StatusBadRequest},
+		{fmt.Errorf("list: %w", &ParamError{"cursor", "x", ErrToken}), http.StatusBadRequest},
+		{formErr, http.StatusBadRequest},
+		{pageErr, http.StatusBadRequest},
+		{fmt.Errorf("list: %w", pageErr), http.StatusBadRequest},
+		{ErrPageNo, http.StatusBadRequest},
+		{sizeErr, http.StatusInternalServerError},
+		{mixedErr, http.StatusInternalServerError},
+		{&ValidationError{}, http.StatusInternalServerError},
+		{ErrNoContext, http.StatusInternalServerError},
+	}
+	for _, st := range tests {
+		if got := StatusCode(st.err); got != st.want {
+			t.Error("StatusCode() for: ", st.err, " Expected: ", st.want, " Got: ", got)
+		}
+	}
+}
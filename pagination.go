@@ -5,74 +5,134 @@
 package pagination
 
 import (
-	"errors"
+	"net/url"
+	"strconv"
+	"sync"
 )
 
-//Args contains the arguments for constructing a New pagination object.
+// Args contains the arguments for constructing a New pagination object.
 type Args struct {
-	Max     int //Maximum amount of pagination entries
-	Pos     int //Position of active page
-	Page    int //Current page
-	Records int //Current results (optional)
-	Total   int //Total amount of records
-	Size    int //Records per page
-}
-
-const (
-	//ErrPageNo is a string constant used in the error returned by pagination.New() whenever the Page number is invalid.
-	ErrPageNo     = errBase + "Page > Pages"
-	errBase       = "Error in pagination: "
-	errInvSize    = errBase + "Size <= 0"
-	errResultSize = errBase + "Results > Size"
-	errRecordSize = errBase + "Results > Records"
-)
+	Max     int  //Maximum amount of pagination entries
+	Pos     int  //Position of active page
+	Page    int  //Current page
+	Records int  //Current results (optional)
+	Total   int  //Total amount of records
+	Size    int  //Records per page
+	Clamp   bool //Clamp Page into 1..Pages, instead of reporting ErrPageMin or ErrPageNo
+
+	Layout   Layout //Arrangement of the pagination entries, Window if nil
+	Boundary int    //Pages always shown at each end, see Layout
+	Siblings int    //Pages shown on each side of the current page, see Layout
+	Jumps    int    //Jump entries shown on each side of the current page, 0 for the default of the Layout
+
+	URL       *url.URL           //Base URL for page links, see Pagination.URL (optional)
+	Param     string             //Name of the page parameter in links, "page" if empty
+	Canonical bool               //Omit the page parameter for page 1 in links
+	Link      func(n int) string //Builds the link to page n, instead of URL, Param and Canonical (optional)
+
+	Labels     Labels      //Texts of the navigation links in the built-in templates, see Templates
+	Translator Translator  //Localizes the labels, the summary and numbers, English if nil
+	JSON       JSONOptions //JSON representation, see Pagination.MarshalJSON
+}
 
 // pages calculates the amount of pages, based on the total amount of records and pages size.
-// Its result is always rounded up.
-// An error is returned if the current page is larger then pages.
-func (a *Args) pages() (p int, err error) {
-	p = (a.Total-1)/a.Size + 1
-	if a.Page > p {
-		err = errors.New(ErrPageNo)
+// Its result is always rounded up. An empty result set has 0 pages.
+func (a *Args) pages() int {
+	if a.Total <= 0 {
+		return 0
 	}
-	return
+	return (a.Total-1)/a.Size + 1
 }
 
-// check does a sanity check on provided values
-func (a *Args) check() (err error) {
+// clamp corrects an out of range Page into 1..Pages.
+// The amount of pages can only be determined for a valid Size, otherwise Page is left alone for check to report.
+func (a *Args) clamp() {
 	if a.Size <= 0 {
-		err = errors.New(errInvSize)
 		return
 	}
+	if p := a.pages(); a.Page > p {
+		a.Page = p
+	}
+	if a.Page < 1 {
+		a.Page = 1
+	}
+}
+
+// check does a sanity check on provided values.
+// All violated constraints are reported at once in a *ValidationError.
+func (a *Args) check() error {
+	v := new(ValidationError)
+	if a.Max < 0 {
+		v.add("Max", a.Max, "Max >= 0", ErrMax)
+	} else if a.Max > 0 && usesPos(a.Layout) && (a.Pos < 1 || a.Pos > a.Max) {
+		v.add("Pos", a.Pos, "1 <= Pos <= Max", ErrPos)
+	}
+	if a.Boundary < 0 {
+		v.add("Boundary", a.Boundary, "Boundary >= 0", ErrBoundary)
+	}
+	if a.Siblings < 0 {
+		v.add("Siblings", a.Siblings, "Siblings >= 0", ErrSiblings)
+	}
+	if a.Jumps < 0 {
+		v.add("Jumps", a.Jumps, "Jumps >= 0", ErrJumps)
+	}
+	if a.Size <= 0 {
+		v.add("Size", a.Size, "Size > 0", ErrSize)
+	}
+	switch {
+	case a.Page < 1:
+		v.add("Page", a.Page, "Page >= 1", ErrPageMin)
+	case a.Page > 1 && a.Size > 0 && a.Page > a.pages(): // Page 1 is valid, even for an empty result set.
+		v.add("Page", a.Page, "Page <= Pages", ErrPageNo)
+	}
+	if a.Total < 0 {
+		v.add("Total", a.Total, "Total >= 0", ErrTotal)
+	}
+	if a.Records < 0 {
+		v.add("Records", a.Records, "Records >= 0", ErrRecords)
+	}
 	if a.Records > a.Size {
-		err = errors.New(errResultSize)
-		return
+		v.add("Records", a.Records, "Records <= Size", ErrRecordsSize)
 	}
 	if a.Records > a.Total {
-		err = errors.New(errRecordSize)
+		v.add("Records", a.Records, "Records <= Total", ErrRecordsTotal)
 	}
-	return
+	return v.err()
 }
 
 // Pagination holds the pagination methods. This object can be passed directly to a html/template.
 // The methods defiend on the object can be called directly from the template.
 type Pagination struct {
-	pages int
-	args  *Args
+	pages     int
+	requested int
+	args      *Args
+	entries   *lazyEntries
+}
+
+// lazyEntries holds the entries of a Pagination, which are computed on first use.
+// It is shared by copies of the Pagination, which only differ in their JSON options.
+type lazyEntries struct {
+	once sync.Once
+	list []Entry
 }
 
 // New creates a new pagination object and return a pointer to it. This method performs some sanity checks on the Args data and returns a nil pointer and an error if a bogus value is supplied.
 //
-// See ErrPageNo for invalid page number.
-// For any other error message, something probably went wrong in the calling code, DB query etc.
+// The error is always a *ValidationError, listing every violated constraint.
+// Use errors.Is(err, ErrPageNo) to detect an invalid page number, which is usually a bad request from the client.
+// For any other error, something probably went wrong in the calling code, DB query etc.
+//
+// If Args.Clamp is set, an out of range Page is corrected into 1..Pages instead.
+// Clamped and RequestedPage report if that happened, so the caller can redirect to the canonical page.
 func New(a Args) (pag *Pagination, err error) {
-	if err = a.check(); err != nil {
-		return
+	requested := a.Page
+	if a.Clamp {
+		a.clamp()
 	}
-	p, err := a.pages()
-	if err != nil {
+	if err = a.check(); err != nil {
 		return
 	}
+	p := a.pages()
 
 	//Determine the needed amount of entries
 	if p < a.Max {
@@ -80,8 +140,10 @@ func New(a Args) (pag *Pagination, err error) {
 	}
 
 	pag = &Pagination{
-		pages: p,
-		args:  &a,
+		pages:     p,
+		requested: requested,
+		args:      &a,
+		entries:   new(lazyEntries),
 	}
 	return
 }
@@ -99,14 +161,52 @@ func (p *Pagination) Page() int {
 	return p.args.Page
 }
 
+// Clamped returns true if the requested page was out of range and corrected by Args.Clamp.
+// Page returns the corrected page number in that case.
+func (p *Pagination) Clamped() bool {
+	return p.requested != p.args.Page
+}
+
+// RequestedPage returns the page number as originally passed in Args.Page, before any clamping.
+func (p *Pagination) RequestedPage() int {
+	return p.requested
+}
+
 // Next returns the number of the next page. It returns 0 if there is no next page.
 func (p *Pagination) Next() int {
-	if p.args.Page == p.pages {
+	if p.args.Page >= p.pages {
 		return 0
 	}
 	return p.args.Page + 1
 }
 
+// HasPrev returns true if there is a previous page.
+func (p *Pagination) HasPrev() bool {
+	return p.Prev() != 0
+}
+
+// HasNext returns true if there is a next page.
+func (p *Pagination) HasNext() bool {
+	return p.Next() != 0
+}
+
+// PrevParam returns the number of the previous page as a string. It returns an empty string if there is no previous page.
+func (p *Pagination) PrevParam() string {
+	return pageParam(p.Prev())
+}
+
+// NextParam returns the number of the next page as a string. It returns an empty string if there is no next page.
+func (p *Pagination) NextParam() string {
+	return pageParam(p.Next())
+}
+
+func pageParam(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
 // Records is a getter wrapper for Args.Records. It returns the current amount of records.
 func (p *Pagination) Records() int {
 	return p.args.Records
@@ -123,35 +223,79 @@ func (p *Pagination) Size() int {
 }
 
 // Pages returns the total number of calculated pages, based on Args.Total and Args.Size.
-// The number of pages is always rounded up.
+// The number of pages is always rounded up. It returns 0 for an empty result set.
 func (p *Pagination) Pages() int {
 	return p.pages
 }
 
+// Empty returns true if the result set is empty (Args.Total == 0).
+// Templates can use it to render a "no results" message instead of the pagination.
+func (p *Pagination) Empty() bool {
+	return p.args.Total == 0
+}
+
 // Entry represents a page number in the pagination range. The active page has the "Active" field set to "true".
+// In the Ellipsis layout, a gap of hidden pages is represented by an Entry with the "Ellipsis" field set to "true" and a zero Number.
 type Entry struct {
-	Active bool // true for the current page, false for any other
-	Number int  // The page number this entry is representing.
+	Active   bool   // true for the current page, false for any other
+	Number   int    // The page number this entry is representing.
+	Ellipsis bool   // true for a gap marker, false for a page number
+	URL      string // Link to the page, see Pagination.URL. Empty for a gap marker.
+
+	rel   string // See Rel
+	label string // See Label
 }
 
 // Entries returns a slice of Entry, over which can be ranged inside the template.
-func (p *Pagination) Entries() (r []Entry) {
-	sn := p.args.Page - p.args.Pos //sn is the start page number of the entries range
-	switch {
-	case sn < 0: //Don't show negative page numbers.
-		sn = 0
-		break
-	case p.args.Max-p.args.Pos+p.args.Page > p.pages:
-		sn = p.pages - p.args.Max
+// The entries are arranged by Args.Layout.
+// The slice is empty for an empty result set.
+//
+// The entries are computed once, on the first call to Entries or AppendEntries, so calling Entries multiple times doesn't allocate.
+// The returned slice is shared and must not be modified, use AppendEntries for a copy.
+func (p *Pagination) Entries() []Entry {
+	e := p.entryList()
+	return e[:len(e):len(e)]
+}
+
+// AppendEntries appends the entries to dst and returns the extended slice, for callers managing their own buffers.
+func (p *Pagination) AppendEntries(dst []Entry) []Entry {
+	return append(dst, p.entryList()...)
+}
+
+// entryList computes the entries on first use and returns them.
+func (p *Pagination) entryList() []Entry {
+	p.entries.once.Do(func() {
+		p.entries.list = p.appendEntries(make([]Entry, 0, p.args.Max))
+	})
+	return p.entries.list
+}
+
+// appendEntries arranges the entries by the layout and sets their URLs.
+func (p *Pagination) appendEntries(dst []Entry) []Entry {
+	n := len(dst)
+	dst = p.layout().AppendEntries(dst, p.args.Page, p.pages, LayoutOptions{
+		Max:      p.args.Max,
+		Pos:      p.args.Pos,
+		Boundary: p.args.Boundary,
+		Siblings: p.args.Siblings,
+		Jumps:    p.args.Jumps,
+	})
+	labels, t := p.Labels(), p.translator()
+	for i := n; i < len(dst); i++ {
+		if dst[i].Ellipsis {
+			continue
+		}
+		dst[i].URL = p.URL(dst[i].Number)
+		dst[i].rel = p.rel(dst[i].Number)
+		dst[i].label = labels.page(t.FormatNumber(dst[i].Number), dst[i].Active)
 	}
-	sn++ //start with number 1
+	return dst
+}
 
-	r = make([]Entry, p.args.Max)
-	for i := 0; i < p.args.Max; i++ {
-		var e Entry
-		e.Number = sn + i
-		e.Active = e.Number == p.args.Page
-		r[i] = e
+// layout returns Args.Layout, or Window if not set.
+func (p *Pagination) layout() Layout {
+	if p.args.Layout == nil {
+		return Window
 	}
-	return
+	return p.args.Layout
 }
@@ -5,111 +5,165 @@
 package pagination
 
 import (
+	"errors"
+	"strconv"
+	"sync"
 	"testing"
 )
 
-var errInvSizeTests = []Args{
-	{5, 3, 1, 10, 100, 0},
-	{5, 3, 1, 10, 100, -1},
+var errTests = []struct {
+	name string
+	want error
+	args []Args
+}{
+	{
+		"ErrSize", ErrSize, []Args{
+			{Max: 5, Pos: 3, Page: 1, Records: 10, Total: 100, Size: 0},
+			{Max: 5, Pos: 3, Page: 1, Records: 10, Total: 100, Size: -1},
+		},
+	},
+	{
+		"ErrRecordsSize", ErrRecordsSize, []Args{
+			{Max: 5, Pos: 3, Page: 1, Records: 11, Total: 100, Size: 10},
+			{Max: 5, Pos: 3, Page: 1, Records: 77, Total: 100, Size: 50},
+		},
+	},
+	{
+		"ErrRecordsTotal", ErrRecordsTotal, []Args{
+			{Max: 5, Pos: 3, Page: 1, Records: 399, Total: 200, Size: 400},
+			{Max: 5, Pos: 3, Page: 1, Records: 101, Total: 5, Size: 300},
+		},
+	},
+	{
+		"ErrPageNo", ErrPageNo, []Args{
+			{Max: 5, Pos: 3, Page: 11, Records: 10, Total: 100, Size: 10},
+			{Max: 5, Pos: 3, Page: 2, Records: 10, Total: 100, Size: 101},
+			{Max: 5, Pos: 3, Page: 2, Records: 0, Total: 0, Size: 10},
+		},
+	},
+	{
+		"ErrPageMin", ErrPageMin, []Args{
+			{Max: 5, Pos: 3, Page: 0, Records: 10, Total: 100, Size: 10},
+			{Max: 5, Pos: 3, Page: -3, Records: 10, Total: 100, Size: 10},
+		},
+	},
+	{
+		"ErrMax", ErrMax, []Args{
+			{Max: -1, Pos: 3, Page: 1, Records: 10, Total: 100, Size: 10},
+			{Max: -9, Pos: 0, Page: 1, Records: 10, Total: 100, Size: 10},
+		},
+	},
+	{
+		"ErrPos", ErrPos, []Args{
+			{Max: 5, Pos: 6, Page: 1, Records: 10, Total: 100, Size: 10},
+			{Max: 5, Pos: 0, Page: 1, Records: 10, Total: 100, Size: 10},
+			{Max: 5, Pos: -1, Page: 1, Records: 10, Total: 100, Size: 10},
+			{Max: 5, Pos: 0, Page: 1, Records: 10, Total: 100, Size: 10, Layout: reversed{}},
+		},
+	},
+	{
+		"ErrBoundary", ErrBoundary, []Args{
+			{Max: 5, Pos: 3, Page: 1, Records: 10, Total: 100, Size: 10, Layout: Ellipsis, Boundary: -1},
+		},
+	},
+	{
+		"ErrSiblings", ErrSiblings, []Args{
+			{Max: 5, Pos: 3, Page: 1, Records: 10, Total: 100, Size: 10, Layout: Ellipsis, Siblings: -2},
+		},
+	},
+	{
+		"ErrJumps", ErrJumps, []Args{
+			{Max: 5, Pos: 3, Page: 1, Records: 10, Total: 100, Size: 10, Layout: Decades, Jumps: -1},
+		},
+	},
+	{
+		"ErrTotal", ErrTotal, []Args{
+			{Max: 5, Pos: 3, Page: 1, Records: 0, Total: -1, Size: 10},
+			{Max: 5, Pos: 3, Page: 1, Records: -5, Total: -100, Size: 10},
+		},
+	},
+	{
+		"ErrRecords", ErrRecords, []Args{
+			{Max: 5, Pos: 3, Page: 1, Records: -1, Total: 100, Size: 10},
+		},
+	},
 }
 
-func TestErrInvSize(t *testing.T) {
-	for _, a := range errInvSizeTests {
-		err := a.check()
-		if err == nil {
-			t.Error(
-				"For: ", a,
-				"; Expected: ", errInvSize,
-				"; Got: ", "nil",
-			)
-			continue
-		}
-		if err.Error() != errInvSize {
-			t.Error(
-				"For: ", a,
-				"; Expected: ", errInvSize,
-				"; Got: ", err.Error(),
-			)
+func TestErr(t *testing.T) {
+	for _, et := range errTests {
+		for _, a := range et.args {
+			err := a.check()
+			if !errors.Is(err, et.want) {
+				t.Error(
+					et.name, " for: ", a,
+					"; Expected: ", et.want,
+					"; Got: ", err,
+				)
+			}
+			if _, err = New(a); !errors.Is(err, et.want) {
+				t.Error(
+					et.name, " New() for: ", a,
+					"; Expected: ", et.want,
+					"; Got: ", err,
+				)
+			}
 		}
 	}
 }
 
-var errResultSizeTests = []Args{
-	{5, 3, 1, 11, 100, 10},
-	{5, 3, 1, 77, 100, 50},
+// validTests are on the edge of the constraints, but should pass.
+var validTests = []Args{
+	{Max: 0, Pos: 0, Page: 1, Records: 10, Total: 100, Size: 10},
+	{Max: 5, Pos: 5, Page: 1, Records: 10, Total: 100, Size: 10},
+	{Max: 5, Pos: 1, Page: 10, Records: 10, Total: 100, Size: 10},
+	{Max: 5, Pos: 3, Page: 1, Records: 10, Total: 10, Size: 10},
+	{Max: 5, Pos: 3, Page: 11, Records: 1, Total: 101, Size: 10},
+	{Max: 9, Pos: 9, Page: 2, Records: 1, Total: 11, Size: 10},
+	{Max: 5, Pos: 3, Page: 1, Records: 0, Total: 0, Size: 10},
 }
 
-func TestErrResultSize(t *testing.T) {
-	for _, a := range errResultSizeTests {
-		err := a.check()
-		if err == nil {
-			t.Error(
-				"For: ", a,
-				"; Expected: ", errResultSize,
-				"; Got: ", "nil",
-			)
+func TestValid(t *testing.T) {
+	for _, a := range validTests {
+		p, err := New(a)
+		if err != nil {
+			t.Error("For: ", a, "; Expected: nil; Got: ", err)
 			continue
 		}
-		if err.Error() != errResultSize {
-			t.Error(
-				"For: ", a,
-				"; Expected: ", errResultSize,
-				"; Got: ", err.Error(),
-			)
+		for _, e := range p.Entries() {
+			if e.Number < 1 || e.Number > p.Pages() {
+				t.Error("Entries() for: ", a, "; Entry out of range: ", e)
+			}
 		}
 	}
 }
 
-var errRecordSizeTests = []Args{
-	{5, 3, 1, 399, 200, 400},
-	{5, 3, 1, 101, 5, 300},
-}
+func TestValidationError(t *testing.T) {
+	a := Args{Max: 5, Pos: 3, Page: 1, Records: 11, Total: 5, Size: 0}
+	_, err := New(a)
 
-func TestErrRecordSize(t *testing.T) {
-	for _, a := range errRecordSizeTests {
-		err := a.check()
-		if err == nil {
-			t.Error(
-				"For: ", a,
-				"; Expected: ", errRecordSize,
-				"; Got: ", "nil",
-			)
-			continue
-		}
-		if err.Error() != errRecordSize {
-			t.Error(
-				"For: ", a,
-				"; Expected: ", errRecordSize,
-				"; Got: ", err.Error(),
-			)
-		}
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatal("For: ", a, "; Expected: *ValidationError; Got: ", err)
 	}
-}
-
-var pagesErrorTests = []Args{
-	{5, 3, 11, 10, 100, 10},
-	{5, 3, 2, 10, 100, 101},
-}
-
-func TestPagesError(t *testing.T) {
-	for _, a := range pagesErrorTests {
-		_, err := a.pages()
-		if err == nil {
-			t.Error(
-				"For: ", a,
-				"; Expected: ", ErrPageNo,
-				"; Got: ", "nil",
-			)
-			continue
+	want := []Violation{
+		{"Size", 0, "Size > 0", ErrSize},
+		{"Records", 11, "Records <= Size", ErrRecordsSize},
+		{"Records", 11, "Records <= Total", ErrRecordsTotal},
+	}
+	if len(verr.Violations) != len(want) {
+		t.Fatal("For: ", a, "; Expected: ", want, "; Got: ", verr.Violations)
+	}
+	for i, v := range verr.Violations {
+		if v != want[i] {
+			t.Error("For: ", a, "; Expected: ", want[i], "; Got: ", v)
 		}
-		if err.Error() != ErrPageNo {
-			t.Error(
-				"For: ", a,
-				"; Expected: ", ErrPageNo,
-				"; Got: ", err.Error(),
-			)
+		if !errors.Is(v, want[i].Err) {
+			t.Error("errors.Is() for: ", v, "; Expected: ", want[i].Err)
 		}
 	}
+	if errors.Is(err, ErrPageNo) {
+		t.Error("For: ", a, "; Unexpected: ", ErrPageNo)
+	}
 }
 
 type testParam struct {
@@ -130,102 +184,155 @@ type testResult struct {
 
 var tests = []testParam{
 	{
-		a: Args{5, 3, 1, 10, 100, 10},
+		a: Args{Max: 5, Pos: 3, Page: 1, Records: 10, Total: 100, Size: 10},
 		r: testResult{
 			0, 1, 2, 10, 100, 10, 10,
 			[]Entry{
-				{true, 1},
-				{false, 2},
-				{false, 3},
-				{false, 4},
-				{false, 5},
+				{Active: true, Number: 1},
+				{Active: false, Number: 2},
+				{Active: false, Number: 3},
+				{Active: false, Number: 4},
+				{Active: false, Number: 5},
 			},
 		},
 	},
 	{
-		a: Args{5, 3, 10, 10, 100, 10},
+		a: Args{Max: 5, Pos: 3, Page: 10, Records: 10, Total: 100, Size: 10},
 		r: testResult{
 			9, 10, 0, 10, 100, 10, 10,
 			[]Entry{
-				{false, 6},
-				{false, 7},
-				{false, 8},
-				{false, 9},
-				{true, 10},
+				{Active: false, Number: 6},
+				{Active: false, Number: 7},
+				{Active: false, Number: 8},
+				{Active: false, Number: 9},
+				{Active: true, Number: 10},
 			},
 		},
 	},
 	{
-		a: Args{5, 3, 5, 10, 100, 10},
+		a: Args{Max: 5, Pos: 3, Page: 5, Records: 10, Total: 100, Size: 10},
 		r: testResult{
 			4, 5, 6, 10, 100, 10, 10,
 			[]Entry{
-				{false, 3},
-				{false, 4},
-				{true, 5},
-				{false, 6},
-				{false, 7},
-			},
-		},
-	},
-	{
-		a: Args{5, 3, 0, 10, 100, 10},
-		r: testResult{
-			0, 0, 1, 10, 100, 10, 10,
-			[]Entry{
-				{false, 1},
-				{false, 2},
-				{false, 3},
-				{false, 4},
-				{false, 5},
+				{Active: false, Number: 3},
+				{Active: false, Number: 4},
+				{Active: true, Number: 5},
+				{Active: false, Number: 6},
+				{Active: false, Number: 7},
 			},
 		},
 	},
 	{
-		a: Args{5, 3, 22, 30, 5000, 30},
+		a: Args{Max: 5, Pos: 3, Page: 22, Records: 30, Total: 5000, Size: 30},
 		r: testResult{
 			21, 22, 23, 30, 5000, 30, 167,
 			[]Entry{
-				{false, 20},
-				{false, 21},
-				{true, 22},
-				{false, 23},
-				{false, 24},
+				{Active: false, Number: 20},
+				{Active: false, Number: 21},
+				{Active: true, Number: 22},
+				{Active: false, Number: 23},
+				{Active: false, Number: 24},
 			},
 		},
 	},
 	{
-		a: Args{5, 3, 9, 10, 100, 10},
+		a: Args{Max: 5, Pos: 3, Page: 9, Records: 10, Total: 100, Size: 10},
 		r: testResult{
 			8, 9, 10, 10, 100, 10, 10,
 			[]Entry{
-				{false, 6},
-				{false, 7},
-				{false, 8},
-				{true, 9},
-				{false, 10},
+				{Active: false, Number: 6},
+				{Active: false, Number: 7},
+				{Active: false, Number: 8},
+				{Active: true, Number: 9},
+				{Active: false, Number: 10},
 			},
 		},
 	},
 	{
-		a: Args{9, 3, 13, 27, 550, 27},
+		a: Args{Max: 9, Pos: 3, Page: 13, Records: 27, Total: 550, Size: 27},
 		r: testResult{
 			12, 13, 14, 27, 550, 27, 21,
 			[]Entry{
-				{false, 11},
-				{false, 12},
-				{true, 13},
-				{false, 14},
-				{false, 15},
-				{false, 16},
-				{false, 17},
-				{false, 18},
-				{false, 19},
+				{Active: false, Number: 11},
+				{Active: false, Number: 12},
+				{Active: true, Number: 13},
+				{Active: false, Number: 14},
+				{Active: false, Number: 15},
+				{Active: false, Number: 16},
+				{Active: false, Number: 17},
+				{Active: false, Number: 18},
+				{Active: false, Number: 19},
 			},
 		},
 	},
 }
 
+func TestEmpty(t *testing.T) {
+	a := Args{Max: 5, Pos: 3, Page: 1, Records: 0, Total: 0, Size: 10}
+	p, err := New(a)
+	if err != nil {
+		t.Fatal("New() for: ", a, " Error: ", err.Error())
+	}
+	if !p.Empty() {
+		t.Error("Empty() for: ", a, " Expected: ", true, " Got: ", p.Empty())
+	}
+	if p.Pages() != 0 {
+		t.Error("Pages() for: ", a, " Expected: ", 0, " Got: ", p.Pages())
+	}
+	if p.Prev() != 0 {
+		t.Error("Prev() for: ", a, " Expected: ", 0, " Got: ", p.Prev())
+	}
+	if p.Next() != 0 {
+		t.Error("Next() for: ", a, " Expected: ", 0, " Got: ", p.Next())
+	}
+	if e := p.Entries(); e == nil || len(e) != 0 {
+		t.Error("Entries() for: ", a, " Expected: ", []Entry{}, " Got: ", e)
+	}
+
+	a.Total, a.Records = 1, 1
+	if p, _ = New(a); p.Empty() {
+		t.Error("Empty() for: ", a, " Expected: ", false, " Got: ", p.Empty())
+	}
+}
+
+var clampTests = []struct {
+	a       Args
+	page    int
+	clamped bool
+}{
+	{Args{Max: 5, Pos: 3, Page: 40, Records: 0, Total: 100, Size: 10, Clamp: true}, 10, true},
+	{Args{Max: 5, Pos: 3, Page: 0, Records: 10, Total: 100, Size: 10, Clamp: true}, 1, true},
+	{Args{Max: 5, Pos: 3, Page: -3, Records: 10, Total: 100, Size: 10, Clamp: true}, 1, true},
+	{Args{Max: 5, Pos: 3, Page: 5, Records: 10, Total: 100, Size: 10, Clamp: true}, 5, false},
+	{Args{Max: 5, Pos: 3, Page: 3, Records: 0, Total: 0, Size: 10, Clamp: true}, 1, true},
+	{Args{Max: 5, Pos: 3, Page: 1, Records: 0, Total: 0, Size: 10, Clamp: true}, 1, false},
+}
+
+func TestClamp(t *testing.T) {
+	for _, ct := range clampTests {
+		p, err := New(ct.a)
+		if err != nil {
+			t.Error("New() for: ", ct.a, " Error: ", err.Error())
+			continue
+		}
+		if p.Page() != ct.page {
+			t.Error("Page() for: ", ct.a, " Expected: ", ct.page, " Got: ", p.Page())
+		}
+		if p.Clamped() != ct.clamped {
+			t.Error("Clamped() for: ", ct.a, " Expected: ", ct.clamped, " Got: ", p.Clamped())
+		}
+		if p.RequestedPage() != ct.a.Page {
+			t.Error("RequestedPage() for: ", ct.a, " Expected: ", ct.a.Page, " Got: ", p.RequestedPage())
+		}
+	}
+
+	// Without a valid Size, there is nothing to clamp into.
+	a := Args{Max: 5, Pos: 3, Page: 40, Records: 0, Total: 100, Size: 0, Clamp: true}
+	if _, err := New(a); !errors.Is(err, ErrSize) {
+		t.Error("New() for: ", a, " Expected: ", ErrSize, " Got: ", err)
+	}
+}
+
 func Test(t *testing.T) {
 	for _, tp := range tests {
 		p, err := New(tp.a)
@@ -269,10 +376,111 @@ func Test(t *testing.T) {
 		}
 
 		for k, e := range entries {
-			if e != tp.r.Entries[k] {
+			if e.Number != tp.r.Entries[k].Number || e.Active != tp.r.Entries[k].Active {
 				t.Error("Entries() for ", tp.a, " Expected: ", tp.r.Entries, " Got: ", entries)
 				break
 			}
 		}
 	}
 }
+
+func TestAppendEntries(t *testing.T) {
+	p, err := New(Args{Max: 5, Pos: 3, Page: 7, Total: 100, Size: 10})
+	if err != nil {
+		t.Fatal("New() Error: ", err.Error())
+	}
+	want := entriesString(p.Entries())
+
+	dst := p.AppendEntries([]Entry{{Ellipsis: true}})
+	if got := entriesString(dst); got != "… "+want {
+		t.Error("AppendEntries() Expected: ", "… "+want, " Got: ", got)
+	}
+	dst[1].Number = 0
+	if got := entriesString(p.Entries()); got != want {
+		t.Error("Entries() after modifying AppendEntries() Expected: ", want, " Got: ", got)
+	}
+
+	// Appending to the shared slice must not affect the next caller.
+	_ = append(p.Entries(), Entry{Number: 99})
+	if got := entriesString(p.Entries()); got != want {
+		t.Error("Entries() after append Expected: ", want, " Got: ", got)
+	}
+
+	if n := testing.AllocsPerRun(10, func() { p.Entries() }); n != 0 {
+		t.Error("Entries() Expected: 0 allocations Got: ", n)
+	}
+}
+
+func TestEntriesLazy(t *testing.T) {
+	p, err := New(Args{Max: 5, Pos: 3, Page: 7, Total: 100, Size: 10})
+	if err != nil {
+		t.Fatal("New() Error: ", err.Error())
+	}
+	if p.entries.list != nil {
+		t.Error("New() Expected: no entries before the first call Got: ", entriesString(p.entries.list))
+	}
+
+	// Concurrent first calls compute the entries once.
+	want := "5 6 [7] 8 9"
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := entriesString(p.AppendEntries(nil)); got != want {
+				t.Error("AppendEntries() Expected: ", want, " Got: ", got)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := entriesString(p.Entries()); got != want {
+		t.Error("Entries() Expected: ", want, " Got: ", got)
+	}
+}
+
+var benchMax = []int{5, 9, 25}
+
+func BenchmarkNew(b *testing.B) {
+	for _, max := range benchMax {
+		a := Args{Max: max, Pos: max / 2, Page: 500, Records: 10, Total: 10000, Size: 10}
+		b.Run(strconv.Itoa(max), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := New(a); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkEntries(b *testing.B) {
+	for _, max := range benchMax {
+		p, err := New(Args{Max: max, Pos: max / 2, Page: 500, Records: 10, Total: 10000, Size: 10})
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(strconv.Itoa(max), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				p.Entries()
+			}
+		})
+	}
+}
+
+func BenchmarkAppendEntries(b *testing.B) {
+	for _, max := range benchMax {
+		p, err := New(Args{Max: max, Pos: max / 2, Page: 500, Records: 10, Total: 10000, Size: 10})
+		if err != nil {
+			b.Fatal(err)
+		}
+		dst := make([]Entry, 0, max)
+		b.Run(strconv.Itoa(max), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				dst = p.AppendEntries(dst[:0])
+			}
+		})
+	}
+}
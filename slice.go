@@ -0,0 +1,50 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import "sort"
+
+// Paginate returns the items on the requested page and their pagination.
+// Total and Records are taken from the slice, all other Args are copied from opts, which may be nil.
+// The page is checked like New does, so a *ValidationError is returned for an out of range page,
+// unless opts.Clamp is set.
+//
+// The returned slice shares its backing array with items, but its capacity is limited,
+// so appending to it doesn't overwrite the items of the next page.
+func Paginate[T any](items []T, page, size int, opts *Args) (s []T, p *Pagination, err error) {
+	var a Args
+	if opts != nil {
+		a = *opts
+	}
+	a.Page, a.Size, a.Total, a.Records = page, size, len(items), 0
+	if p, err = New(a); err != nil {
+		return
+	}
+	start, end := p.Range()
+	p.args.Records = end - start
+	s = items[start:end:end]
+	return
+}
+
+// PaginateFunc is like Paginate, for the items for which keep returns true, ordered by less.
+// Both keep and less are optional. The sort is stable and doesn't modify items.
+// It is meant for small lists which are handled entirely in memory, like admin pages.
+func PaginateFunc[T any](items []T, page, size int, opts *Args, keep func(T) bool, less func(a, b T) bool) ([]T, *Pagination, error) {
+	if keep != nil || less != nil {
+		filtered := make([]T, 0, len(items))
+		for _, item := range items {
+			if keep == nil || keep(item) {
+				filtered = append(filtered, item)
+			}
+		}
+		if less != nil {
+			sort.SliceStable(filtered, func(i, j int) bool {
+				return less(filtered[i], filtered[j])
+			})
+		}
+		items = filtered
+	}
+	return Paginate(items, page, size, opts)
+}
@@ -0,0 +1,95 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var numbers = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23}
+
+var paginateTests = []struct {
+	page, size int
+	opts       *Args
+	want       string
+	records    int
+}{
+	{1, 10, nil, "[1 2 3 4 5 6 7 8 9 10]", 10},
+	{2, 10, nil, "[11 12 13 14 15 16 17 18 19 20]", 10},
+	{3, 10, nil, "[21 22 23]", 3},
+	{9, 10, &Args{Clamp: true}, "[21 22 23]", 3},
+	{1, 30, nil, "[1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23]", 23},
+	{2, 10, &Args{Max: 3, Pos: 2, Records: 99, Total: 99}, "[11 12 13 14 15 16 17 18 19 20]", 10},
+}
+
+func TestPaginate(t *testing.T) {
+	for _, pt := range paginateTests {
+		s, p, err := Paginate(numbers, pt.page, pt.size, pt.opts)
+		if err != nil {
+			t.Error("Paginate() for: ", pt.page, pt.size, " Error: ", err.Error())
+			continue
+		}
+		if got := fmt.Sprint(s); got != pt.want {
+			t.Error("Paginate() for: ", pt.page, pt.size, " Expected: ", pt.want, " Got: ", got)
+		}
+		if p.Records() != pt.records || p.Total() != len(numbers) {
+			t.Error("Paginate() for: ", pt.page, pt.size, " Expected: ", pt.records, len(numbers), " Got: ", p.Records(), p.Total())
+		}
+	}
+
+	empty, p, err := Paginate([]string{}, 1, 10, nil)
+	if err != nil || len(empty) != 0 || !p.Empty() {
+		t.Error("Paginate() for empty slice Got: ", empty, err)
+	}
+
+	if _, _, err = Paginate(numbers, 4, 10, nil); !errors.Is(err, ErrPageNo) {
+		t.Error("Paginate() Expected: ", ErrPageNo, " Got: ", err)
+	}
+	if _, _, err = Paginate(numbers, 1, 0, nil); !errors.Is(err, ErrSize) {
+		t.Error("Paginate() Expected: ", ErrSize, " Got: ", err)
+	}
+
+	// Appending to a page must not overwrite the next page.
+	s, _, _ := Paginate(numbers, 1, 10, nil)
+	_ = append(s, 0)
+	if numbers[10] != 11 {
+		t.Error("append() overwrote items: ", numbers)
+	}
+}
+
+func TestPaginateFunc(t *testing.T) {
+	words := strings.Fields("pear apple fig banana kiwi cherry plum date")
+	short := func(s string) bool { return len(s) <= 4 }
+	byLen := func(a, b string) bool { return len(a) < len(b) }
+
+	tests := []struct {
+		keep func(string) bool
+		less func(a, b string) bool
+		page int
+		want string
+	}{
+		{nil, nil, 1, "[pear apple fig]"},
+		{short, nil, 1, "[pear fig kiwi]"},
+		{short, nil, 2, "[plum date]"},
+		{nil, byLen, 1, "[fig pear kiwi]"},
+		{short, byLen, 2, "[plum date]"},
+	}
+	for _, pt := range tests {
+		s, _, err := PaginateFunc(words, pt.page, 3, nil, pt.keep, pt.less)
+		if err != nil {
+			t.Error("PaginateFunc() for: ", pt.page, " Error: ", err.Error())
+			continue
+		}
+		if got := fmt.Sprint(s); got != pt.want {
+			t.Error("PaginateFunc() for: ", pt.page, " Expected: ", pt.want, " Got: ", got)
+		}
+	}
+	if words[0] != "pear" || words[1] != "apple" {
+		t.Error("PaginateFunc() modified items: ", words)
+	}
+}
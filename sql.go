@@ -0,0 +1,90 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"strconv"
+)
+
+// Offset returns the amount of records to skip for the current page: (Page-1)*Size.
+// It never returns a negative number, even for unchecked Args.
+func (a *Args) Offset() int {
+	if a.Page < 1 || a.Size < 0 {
+		return 0
+	}
+	return (a.Page - 1) * a.Size
+}
+
+// Limit returns the maximum amount of records on the current page, which is Size.
+func (a *Args) Limit() int {
+	return a.Size
+}
+
+// Range returns the zero based, half-open range of records on the current page,
+// so that records[start:end] are the ones shown. End is clamped to Total.
+func (a *Args) Range() (start, end int) {
+	start = a.Offset()
+	end = start + a.Limit()
+	if end > a.Total {
+		end = a.Total
+	}
+	if end < start {
+		end = start
+	}
+	return
+}
+
+// Offset returns the amount of records to skip for the current page. See Args.Offset.
+func (p *Pagination) Offset() int {
+	return p.args.Offset()
+}
+
+// Limit returns the maximum amount of records on the current page. See Args.Limit.
+func (p *Pagination) Limit() int {
+	return p.args.Limit()
+}
+
+// Range returns the zero based, half-open range of records on the current page. See Args.Range.
+func (p *Pagination) Range() (start, end int) {
+	return p.args.Range()
+}
+
+// Dialect is a SQL dialect. It determines the syntax of the limit clause and of the placeholders.
+type Dialect int
+
+// Supported SQL dialects.
+const (
+	Postgres  Dialect = iota // LIMIT $1 OFFSET $2
+	MySQL                    // LIMIT ? OFFSET ?
+	SQLite                   // LIMIT ? OFFSET ?
+	SQLServer                // OFFSET @p1 ROWS FETCH NEXT @p2 ROWS ONLY
+)
+
+// Placeholder returns the placeholder for the n-th (1 based) query argument.
+func (d Dialect) Placeholder(n int) string {
+	switch d {
+	case Postgres:
+		return "$" + strconv.Itoa(n)
+	case SQLServer:
+		return "@p" + strconv.Itoa(n)
+	default:
+		return "?"
+	}
+}
+
+// AppendLimit appends the limit clause of the dialect to query and the limit and offset to args.
+// The placeholders are numbered after the existing args.
+// Note that SQL Server requires an ORDER BY clause in the query.
+//
+//	query, args = pagination.Postgres.AppendLimit("SELECT * FROM articles ORDER BY id", nil, pag.Limit(), pag.Offset())
+func (d Dialect) AppendLimit(query string, args []interface{}, limit, offset int) (string, []interface{}) {
+	n := len(args)
+	if d == SQLServer {
+		query += " OFFSET " + d.Placeholder(n+1) + " ROWS FETCH NEXT " + d.Placeholder(n+2) + " ROWS ONLY"
+		return query, append(args, offset, limit)
+	}
+	query += " LIMIT " + d.Placeholder(n+1) + " OFFSET " + d.Placeholder(n+2)
+	return query, append(args, limit, offset)
+}
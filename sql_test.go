@@ -0,0 +1,94 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"reflect"
+	"testing"
+)
+
+var offsetTests = []struct {
+	a                         Args
+	offset, limit, start, end int
+}{
+	{Args{Page: 1, Total: 100, Size: 10}, 0, 10, 0, 10},
+	{Args{Page: 10, Total: 100, Size: 10}, 90, 10, 90, 100},
+	{Args{Page: 38, Total: 150, Size: 4}, 148, 4, 148, 150},
+	{Args{Page: 1, Total: 0, Size: 10}, 0, 10, 0, 0},
+	{Args{Page: 0, Total: 100, Size: 10}, 0, 10, 0, 10},
+	{Args{Page: 20, Total: 100, Size: 10}, 190, 10, 190, 190},
+}
+
+func TestOffset(t *testing.T) {
+	for _, ot := range offsetTests {
+		if got := ot.a.Offset(); got != ot.offset {
+			t.Error("Offset() for: ", ot.a, " Expected: ", ot.offset, " Got: ", got)
+		}
+		if got := ot.a.Limit(); got != ot.limit {
+			t.Error("Limit() for: ", ot.a, " Expected: ", ot.limit, " Got: ", got)
+		}
+		if start, end := ot.a.Range(); start != ot.start || end != ot.end {
+			t.Error("Range() for: ", ot.a, " Expected: ", ot.start, ot.end, " Got: ", start, end)
+		}
+
+		p, err := New(ot.a)
+		if err != nil {
+			continue // Only valid Args can be tested through Pagination.
+		}
+		if p.Offset() != ot.offset || p.Limit() != ot.limit {
+			t.Error("Offset(), Limit() for: ", ot.a, " Got: ", p.Offset(), p.Limit())
+		}
+		if start, end := p.Range(); start != ot.start || end != ot.end {
+			t.Error("Range() for: ", ot.a, " Expected: ", ot.start, ot.end, " Got: ", start, end)
+		}
+	}
+}
+
+// TestOffsetPages checks the consistency of Offset with the page math in Args.pages:
+// the last page must start before Total and the one after it must not.
+func TestOffsetPages(t *testing.T) {
+	for total := 1; total < 50; total++ {
+		for size := 1; size < 12; size++ {
+			a := Args{Total: total, Size: size}
+			a.Page = a.pages()
+			if a.Offset() >= total {
+				t.Error("Offset() of last page for: ", a, " >= Total")
+			}
+			a.Page++
+			if a.Offset() < total {
+				t.Error("Offset() beyond last page for: ", a, " < Total")
+			}
+		}
+	}
+}
+
+func TestAppendLimit(t *testing.T) {
+	tests := []struct {
+		d     Dialect
+		query string
+	}{
+		{Postgres, "SELECT x WHERE a = $1 LIMIT $2 OFFSET $3"},
+		{MySQL, "SELECT x WHERE a = ? LIMIT ? OFFSET ?"},
+		{SQLite, "SELECT x WHERE a = ? LIMIT ? OFFSET ?"},
+		{SQLServer, "SELECT x WHERE a = @p1 ORDER BY id OFFSET @p2 ROWS FETCH NEXT @p3 ROWS ONLY"},
+	}
+	for _, tt := range tests {
+		base := "SELECT x WHERE a = " + tt.d.Placeholder(1)
+		if tt.d == SQLServer {
+			base += " ORDER BY id"
+		}
+		query, args := tt.d.AppendLimit(base, []interface{}{"tim"}, 10, 30)
+		if query != tt.query {
+			t.Error("AppendLimit() for: ", tt.d, " Expected: ", tt.query, " Got: ", query)
+		}
+		want := []interface{}{"tim", 10, 30}
+		if tt.d == SQLServer {
+			want = []interface{}{"tim", 30, 10}
+		}
+		if !reflect.DeepEqual(args, want) {
+			t.Error("AppendLimit() args for: ", tt.d, " Expected: ", want, " Got: ", args)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"embed"
+	"html/template"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+// Labels are the texts of the navigation links in the built-in templates.
+// Empty fields are replaced by the ones of the Translator, or else DefaultLabels.
+type Labels struct {
+	First string // Link to the first page
+	Prev  string // Link to the previous page
+	Next  string // Link to the next page
+	Last  string // Link to the last page
+	Gap   string // Gap of hidden pages, see Ellipsis
+
+	Nav     string // Accessible name of the navigation, see Pagination.Label
+	Page    string // Accessible name of a page link, %d is replaced by the page number, see Entry.Label
+	Current string // Accessible name of the current page link, %d is replaced by the page number
+}
+
+// DefaultLabels are used for empty Labels fields.
+var DefaultLabels = Labels{
+	First: "First",
+	Prev:  "Previous",
+	Next:  "Next",
+	Last:  "Last",
+	Gap:   "…",
+
+	Nav:     "Pagination",
+	Page:    "Page %d",
+	Current: "Page %d, current page",
+}
+
+// Labels returns Args.Labels, with empty fields replaced by the labels of Args.Translator and then by DefaultLabels.
+func (p *Pagination) Labels() Labels {
+	l := p.args.Labels.or(p.translator().Labels())
+	return l.or(DefaultLabels)
+}
+
+// or returns l, with empty fields replaced by the ones in def.
+func (l Labels) or(def Labels) Labels {
+	return Labels{
+		First: or(l.First, def.First),
+		Prev:  or(l.Prev, def.Prev),
+		Next:  or(l.Next, def.Next),
+		Last:  or(l.Last, def.Last),
+		Gap:   or(l.Gap, def.Gap),
+
+		Nav:     or(l.Nav, def.Nav),
+		Page:    or(l.Page, def.Page),
+		Current: or(l.Current, def.Current),
+	}
+}
+
+// or returns s, or def if s is empty.
+func or(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+// Templates adds the built-in pagination templates to t and returns t. The templates are:
+//
+//	pagination/bootstrap4   Bootstrap 4
+//	pagination/bootstrap5   Bootstrap 5
+//	pagination/bulma        Bulma
+//	pagination/tailwind     Tailwind CSS
+//	pagination/plain        semantic HTML without classes, for your own CSS
+//	pagination/accessible   reference markup following the WCAG 2.1 guidance, see Entry.Label
+//
+// They expect a *Pagination and render nothing for an empty result set:
+//
+//	t := template.Must(pagination.Templates(template.Must(template.ParseFiles("layout.html"))))
+//	...
+//	{{template "pagination/bootstrap5" .Pagination}}
+//
+// The texts of the links are set by Args.Labels and the links themselves by Args.URL, or Args.Link for a custom scheme.
+func Templates(t *template.Template) (*template.Template, error) {
+	return t.ParseFS(templateFS, "templates/*.html")
+}
@@ -0,0 +1,89 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"flag"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+var templateNames = []string{"bootstrap4", "bootstrap5", "bulma", "tailwind", "plain", "accessible"}
+
+var templateTests = []struct {
+	name string
+	a    Args
+}{
+	{"first", Args{Max: 5, Pos: 3, Page: 1, Total: 30, Size: 10}},
+	{"middle", Args{Page: 9, Total: 1000, Size: 10, Layout: Ellipsis, Boundary: 1, Siblings: 1, Param: "p", Canonical: true}},
+	{"last", Args{Max: 5, Pos: 3, Page: 3, Total: 30, Size: 10, Labels: Labels{Prev: "« Back", Next: "More »"}}},
+	{"link", Args{Max: 3, Pos: 2, Page: 2, Total: 30, Size: 10, Link: func(n int) string { return "/articles/" + strconv.Itoa(n) + "?a<b" }}},
+	{"large", Args{Max: 3, Pos: 2, Page: 1500, Total: 50000, Size: 10, Translator: German}},
+}
+
+// golden compares got to the golden file, or updates it with the -update flag.
+func golden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Error("Render of: ", name, " Expected: ", string(want), " Got: ", got)
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	tmpl, err := Templates(template.New("test"))
+	if err != nil {
+		t.Fatal("Templates() Error: ", err.Error())
+	}
+	for _, tt := range templateTests {
+		p, err := New(tt.a)
+		if err != nil {
+			t.Error("New() for: ", tt.name, " Error: ", err.Error())
+			continue
+		}
+		for _, name := range templateNames {
+			var b strings.Builder
+			if err = tmpl.ExecuteTemplate(&b, "pagination/"+name, p); err != nil {
+				t.Error("ExecuteTemplate() for: ", name, " Error: ", err.Error())
+				continue
+			}
+			golden(t, name+"_"+tt.name, b.String())
+		}
+	}
+
+	// Nothing is rendered for an empty result set.
+	p, _ := New(Args{Max: 5, Pos: 3, Page: 1, Total: 0, Size: 10})
+	for _, name := range templateNames {
+		var b strings.Builder
+		if err = tmpl.ExecuteTemplate(&b, "pagination/"+name, p); err != nil || strings.TrimSpace(b.String()) != "" {
+			t.Error("ExecuteTemplate() for empty: ", name, " Expected: nothing Got: ", b.String(), err)
+		}
+	}
+}
+
+func TestLabels(t *testing.T) {
+	p, _ := New(Args{Page: 1, Total: 30, Size: 10, Labels: Labels{Next: "Volgende"}})
+	want := DefaultLabels
+	want.Next = "Volgende"
+	if got := p.Labels(); got != want {
+		t.Error("Labels() Expected: ", want, " Got: ", got)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// param returns the name of the page parameter in links.
+func (p *Pagination) param() string {
+	if p.args.Param == "" {
+		return "page"
+	}
+	return p.args.Param
+}
+
+// URL returns the link to page n. It keeps all other query parameters of Args.URL, like search filters and sort order.
+// If Args.URL is nil, a relative link like "?page=2" is returned.
+// With Args.Canonical set, the page parameter is omitted for page 1.
+// If Args.Link is set, it builds the link instead, for example for page numbers in the path.
+// It returns an empty string if n < 1, so it can be used directly with Prev and Next.
+func (p *Pagination) URL(n int) string {
+	if n < 1 {
+		return ""
+	}
+	if p.args.Link != nil {
+		return p.args.Link(n)
+	}
+	var u url.URL
+	if p.args.URL != nil {
+		u = *p.args.URL
+	}
+	q := u.Query()
+	if n == 1 && p.args.Canonical {
+		q.Del(p.param())
+	} else {
+		q.Set(p.param(), strconv.Itoa(n))
+	}
+	u.RawQuery = q.Encode()
+	if s := u.String(); s != "" {
+		return s
+	}
+	return "?" // Empty query on the current path
+}
+
+// PrevURL returns the link to the previous page. It returns an empty string if there is no previous page.
+func (p *Pagination) PrevURL() string {
+	return p.URL(p.Prev())
+}
+
+// NextURL returns the link to the next page. It returns an empty string if there is no next page.
+func (p *Pagination) NextURL() string {
+	return p.URL(p.Next())
+}
+
+// FirstURL returns the link to the first page.
+func (p *Pagination) FirstURL() string {
+	return p.URL(1)
+}
+
+// LastURL returns the link to the last page. It returns an empty string for an empty result set.
+func (p *Pagination) LastURL() string {
+	return p.URL(p.pages)
+}
@@ -0,0 +1,102 @@
+// Copyright 2018 Tim Möhlmann. All rights reserved.
+// This project is licensed under the BSD 3-Clause
+// See the LICENSE file for details.
+
+package pagination
+
+import (
+	"html/template"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func mustParseURL(s string) *url.URL {
+	u, err := url.Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+var urlTests = []struct {
+	a                               Args
+	prev, next, first, last, entry1 string
+}{
+	{
+		Args{Max: 3, Pos: 2, Page: 1, Total: 30, Size: 10},
+		"", "?page=2", "?page=1", "?page=3", "?page=1",
+	},
+	{
+		Args{Max: 3, Pos: 2, Page: 2, Total: 30, Size: 10, Canonical: true},
+		"?", "?page=3", "?", "?page=3", "?",
+	},
+	{
+		Args{Max: 3, Pos: 2, Page: 2, Total: 30, Size: 10, URL: mustParseURL("/search?q=go+lang&sort=date&page=9#results")},
+		"/search?page=1&q=go+lang&sort=date#results", "/search?page=3&q=go+lang&sort=date#results",
+		"/search?page=1&q=go+lang&sort=date#results", "/search?page=3&q=go+lang&sort=date#results",
+		"/search?page=1&q=go+lang&sort=date#results",
+	},
+	{
+		Args{Max: 3, Pos: 2, Page: 2, Total: 30, Size: 10, URL: mustParseURL("https://example.com/list?q=a%26b"), Param: "p", Canonical: true},
+		"https://example.com/list?q=a%26b", "https://example.com/list?p=3&q=a%26b",
+		"https://example.com/list?q=a%26b", "https://example.com/list?p=3&q=a%26b",
+		"https://example.com/list?q=a%26b",
+	},
+	{
+		Args{Max: 3, Pos: 2, Page: 1, Total: 0, Size: 10},
+		"", "", "?page=1", "", "",
+	},
+}
+
+func TestURL(t *testing.T) {
+	for _, ut := range urlTests {
+		p, err := New(ut.a)
+		if err != nil {
+			t.Error("New() for: ", ut.a, " Error: ", err.Error())
+			continue
+		}
+		if got := p.PrevURL(); got != ut.prev {
+			t.Error("PrevURL() for: ", ut.a, " Expected: ", ut.prev, " Got: ", got)
+		}
+		if got := p.NextURL(); got != ut.next {
+			t.Error("NextURL() for: ", ut.a, " Expected: ", ut.next, " Got: ", got)
+		}
+		if got := p.FirstURL(); got != ut.first {
+			t.Error("FirstURL() for: ", ut.a, " Expected: ", ut.first, " Got: ", got)
+		}
+		if got := p.LastURL(); got != ut.last {
+			t.Error("LastURL() for: ", ut.a, " Expected: ", ut.last, " Got: ", got)
+		}
+		var got string
+		if e := p.Entries(); len(e) > 0 {
+			got = e[0].URL
+		}
+		if got != ut.entry1 {
+			t.Error("Entries()[0].URL for: ", ut.a, " Expected: ", ut.entry1, " Got: ", got)
+		}
+	}
+}
+
+func TestURLBaseUnchanged(t *testing.T) {
+	u := mustParseURL("/search?q=go")
+	p, _ := New(Args{Page: 2, Total: 30, Size: 10, URL: u})
+	p.NextURL()
+	if u.String() != "/search?q=go" {
+		t.Error("URL() modified Args.URL: ", u)
+	}
+}
+
+func TestURLTemplate(t *testing.T) {
+	tmpl := template.Must(template.New("").Parse(`{{range .Entries}}<a href="{{.URL}}">{{.Number}}</a>{{end}}`))
+	p, _ := New(Args{Max: 2, Pos: 1, Page: 1, Total: 20, Size: 10, URL: mustParseURL(`/s?q="><script>`)})
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, p); err != nil {
+		t.Fatal(err)
+	}
+	want := `<a href="/s?page=1&amp;q=%22%3E%3Cscript%3E">1</a><a href="/s?page=2&amp;q=%22%3E%3Cscript%3E">2</a>`
+	if b.String() != want {
+		t.Error("Template Expected: ", want, " Got: ", b.String())
+	}
+}